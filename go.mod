@@ -2,4 +2,7 @@ module example/paypalv2
 
 go 1.16
 
-require github.com/astaxie/beego v1.12.3
+require (
+	github.com/astaxie/beego v1.12.3
+	gopkg.in/yaml.v2 v2.2.8
+)
@@ -12,15 +12,31 @@ import (
 	"context"
 	"example/paypalv2/paypal"
 	"fmt"
+	"net/http"
+	"os"
 )
 
 func main()  {
-	c,err:=paypal.PaypalClient("*****","***",paypal.APIBaseSandBox)
+	// Credentials come from paypal.yaml (see paypal.example.yaml) or PAYPAL_<ACCOUNT>_* variables
+	path := os.Getenv("PAYPAL_CONFIG")
+	if path == "" {
+		path = "paypal.yaml"
+	}
+	cfg,err:=paypal.LoadConfig(path)
 	if err!=nil{
 		fmt.Println(err)
+		return
+	}
+	account,err:=cfg.Account("")
+	if err!=nil{
+		fmt.Println(err)
+		return
+	}
+	c,err:=account.NewClient()
+	if err!=nil{
+		fmt.Println(err)
+		return
 	}
-
-	c.GetAccessToken(context.Background())
 
 	var amout  paypal.PurchaseUnitAmount
 	amout.Currency="USD"
@@ -37,10 +53,27 @@ func main()  {
 
 	createOrder.Intent = "CAPTURE"
 	createOrder.PurchaseUnits = purchaseUnits
-	createOrder.ApplicationContext.ReturnURL="http://pages.ylwtd.com/paypal-result.html"
+	createOrder.ApplicationContext = account.ApplicationContext()
 	order, err := c.CreateOrder(context.Background(),createOrder)
 	if err!=nil{
 		fmt.Println(err)
+		return
 	}
 	fmt.Println(order.Links)
+
+	// With PAYPAL_WEBHOOK_ADDR set, wait for webhook deliveries verified against the account's webhook_id
+	addr := os.Getenv("PAYPAL_WEBHOOK_ADDR")
+	if addr == "" {
+		return
+	}
+	handler,err:=account.WebhookHandler(c)
+	if err!=nil{
+		fmt.Println(err)
+		return
+	}
+	handler.OnCheckoutOrderApproved(func(ctx context.Context, event *paypal.WebhookEvent, approved *paypal.Order) error {
+		fmt.Println("order approved:", approved.ID)
+		return nil
+	})
+	fmt.Println(http.ListenAndServe(addr, handler))
 }
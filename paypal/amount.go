@@ -0,0 +1,286 @@
+/**
+ * @ClassName amount
+ * @Description purchase unit amount breakdown computation and validation
+ * @Author liwei
+ * @Date 2026/10/18 12:30
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AmountBreakdownOptions holds the purchase unit charges and discounts that
+// don't come from items, in PayPal's string format. Empty fields are left out.
+type AmountBreakdownOptions struct {
+	Shipping         string
+	Handling         string
+	Insurance        string
+	ShippingDiscount string
+	Discount         string
+}
+
+// AmountMismatchError describes why a PurchaseUnitAmount would be rejected by
+// PayPal. Issue is the issue code PayPal itself would answer with.
+type AmountMismatchError struct {
+	Issue    string
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (e *AmountMismatchError) Error() string {
+	if e.Expected == "" {
+		return fmt.Sprintf("paypal: %s: %s is missing", e.Issue, e.Field)
+	}
+	return fmt.Sprintf("paypal: %s: %s is %s, expected %s", e.Issue, e.Field, e.Actual, e.Expected)
+}
+
+// BuildPurchaseUnitAmount derives the amount and its breakdown from items:
+// item_total is the sum of unit_amount × quantity, tax_total the sum of
+// tax × quantity, and value adds opts charges and subtracts opts discounts.
+func BuildPurchaseUnitAmount(currency string, items []Item, opts *AmountBreakdownOptions) (*PurchaseUnitAmount, error) {
+	itemTotal, taxTotal, hasTax, err := sumItems(currency, items)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &AmountBreakdownOptions{}
+	}
+
+	breakdown := &PurchaseUnitAmountBreakdown{ItemTotal: itemTotal.Money()}
+	if hasTax {
+		breakdown.TaxTotal = taxTotal.Money()
+	}
+	fields := []struct {
+		value string
+		dst   **Money
+	}{
+		{opts.Shipping, &breakdown.Shipping},
+		{opts.Handling, &breakdown.Handling},
+		{opts.Insurance, &breakdown.Insurance},
+		{opts.ShippingDiscount, &breakdown.ShippingDiscount},
+		{opts.Discount, &breakdown.Discount},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		v, err := ParseMoney(currency, f.value)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = v.Money()
+	}
+
+	total, err := breakdownTotal(currency, breakdown)
+	if err != nil {
+		return nil, err
+	}
+	if total.IsNegative() || total.IsZero() {
+		return nil, &AmountMismatchError{Issue: IssueCannotBeZeroOrNegative, Field: "value", Actual: total.String(), Expected: "a positive amount"}
+	}
+
+	return &PurchaseUnitAmount{Currency: currency, Value: total.String(), Breakdown: breakdown}, nil
+}
+
+// Validate checks the amount the way PayPal does before creating an order:
+// value must be positive and equal the breakdown total, and when items are
+// given item_total and tax_total must match them. A tax_total without taxed
+// items must be zero. It returns an *AmountMismatchError for the first
+// mismatch, or a parse error for malformed amounts.
+func (a *PurchaseUnitAmount) Validate(items []Item) error {
+	value, err := ParseMoney(a.Currency, a.Value)
+	if err != nil {
+		return err
+	}
+	if value.IsNegative() || value.IsZero() {
+		return &AmountMismatchError{Issue: IssueCannotBeZeroOrNegative, Field: "value", Actual: value.String(), Expected: "a positive amount"}
+	}
+
+	if a.Breakdown == nil {
+		if len(items) > 0 {
+			return &AmountMismatchError{Issue: IssueItemTotalRequired, Field: "breakdown.item_total"}
+		}
+		return nil
+	}
+
+	total, err := breakdownTotal(a.Currency, a.Breakdown)
+	if err != nil {
+		return err
+	}
+	if !total.Equal(value) {
+		return &AmountMismatchError{Issue: IssueAmountMismatch, Field: "value", Expected: total.String(), Actual: value.String()}
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	itemTotal, taxTotal, hasTax, err := sumItems(a.Currency, items)
+	if err != nil {
+		return err
+	}
+	if err = checkBreakdownField(a.Currency, "breakdown.item_total", a.Breakdown.ItemTotal, itemTotal, IssueItemTotalMismatch, IssueItemTotalRequired); err != nil {
+		return err
+	}
+	if hasTax || a.Breakdown.TaxTotal != nil {
+		if err = checkBreakdownField(a.Currency, "breakdown.tax_total", a.Breakdown.TaxTotal, taxTotal, IssueTaxTotalMismatch, IssueTaxTotalRequired); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks Amount against Items, see PurchaseUnitAmount.Validate
+func (p *PurchaseUnitRequest) Validate() error {
+	if p.Amount == nil {
+		return &AmountMismatchError{Issue: IssueAmountMismatch, Field: "amount"}
+	}
+	return p.Amount.Validate(p.Items)
+}
+
+// Validate checks Amount against Items, see PurchaseUnitAmount.Validate
+func (p *PurchaseUnit) Validate() error {
+	if p.Amount == nil {
+		return &AmountMismatchError{Issue: IssueAmountMismatch, Field: "amount"}
+	}
+	return p.Amount.Validate(p.Items)
+}
+
+// Validate checks every purchase unit, see PurchaseUnitAmount.Validate
+func (o *CreateOrder) Validate() error {
+	for i := range o.PurchaseUnits {
+		if err := o.PurchaseUnits[i].Validate(); err != nil {
+			return fmt.Errorf("purchase_units[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validatePurchaseUnitRequests(purchaseUnits []PurchaseUnitRequest) error {
+	for i := range purchaseUnits {
+		if err := purchaseUnits[i].Validate(); err != nil {
+			return fmt.Errorf("purchase_units[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func checkBreakdownField(currency, field string, got *Money, want MoneyValue, mismatchIssue, requiredIssue string) error {
+	if got == nil {
+		return &AmountMismatchError{Issue: requiredIssue, Field: field}
+	}
+	v, err := parseBreakdownMoney(currency, field, got)
+	if err != nil {
+		return err
+	}
+	if !v.Equal(want) {
+		return &AmountMismatchError{Issue: mismatchIssue, Field: field, Expected: want.String(), Actual: v.String()}
+	}
+	return nil
+}
+
+// sumItems returns the item total and tax total of items, hasTax is false
+// when no item carries a tax
+func sumItems(currency string, items []Item) (itemTotal, taxTotal MoneyValue, hasTax bool, err error) {
+	if itemTotal, err = NewMoneyValue(currency, 0); err != nil {
+		return
+	}
+	taxTotal = itemTotal
+
+	for i, item := range items {
+		field := fmt.Sprintf("items[%d]", i)
+		quantity, qErr := strconv.ParseInt(item.Quantity, 10, 64)
+		if qErr != nil || quantity <= 0 {
+			err = fmt.Errorf("%w: %s.quantity %q", ErrInvalidAmount, field, item.Quantity)
+			return
+		}
+		if item.UnitAmount == nil {
+			err = &AmountMismatchError{Issue: IssueItemTotalMismatch, Field: field + ".unit_amount"}
+			return
+		}
+
+		var line MoneyValue
+		if line, err = lineTotal(currency, field+".unit_amount", item.UnitAmount, quantity); err != nil {
+			return
+		}
+		if itemTotal, err = itemTotal.Add(line); err != nil {
+			return
+		}
+
+		if item.Tax != nil {
+			hasTax = true
+			if line, err = lineTotal(currency, field+".tax", item.Tax, quantity); err != nil {
+				return
+			}
+			if taxTotal, err = taxTotal.Add(line); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func lineTotal(currency, field string, unit *Money, quantity int64) (MoneyValue, error) {
+	v, err := parseBreakdownMoney(currency, field, unit)
+	if err != nil {
+		return MoneyValue{}, err
+	}
+	return v.Mul(quantity)
+}
+
+// breakdownTotal returns item_total + tax_total + shipping + handling + insurance - shipping_discount - discount
+func breakdownTotal(currency string, b *PurchaseUnitAmountBreakdown) (MoneyValue, error) {
+	total, err := NewMoneyValue(currency, 0)
+	if err != nil {
+		return total, err
+	}
+
+	fields := []struct {
+		name     string
+		money    *Money
+		subtract bool
+	}{
+		{"breakdown.item_total", b.ItemTotal, false},
+		{"breakdown.tax_total", b.TaxTotal, false},
+		{"breakdown.shipping", b.Shipping, false},
+		{"breakdown.handling", b.Handling, false},
+		{"breakdown.insurance", b.Insurance, false},
+		{"breakdown.shipping_discount", b.ShippingDiscount, true},
+		{"breakdown.discount", b.Discount, true},
+	}
+	for _, f := range fields {
+		if f.money == nil {
+			continue
+		}
+		v, err := parseBreakdownMoney(currency, f.name, f.money)
+		if err != nil {
+			return total, err
+		}
+		if f.subtract {
+			total, err = total.Sub(v)
+		} else {
+			total, err = total.Add(v)
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func parseBreakdownMoney(currency, field string, m *Money) (MoneyValue, error) {
+	if m.Currency != currency {
+		return MoneyValue{}, fmt.Errorf("%w: %s is %s, amount is %s", ErrCurrencyMismatch, field, m.Currency, currency)
+	}
+	v, err := m.MoneyValue()
+	if err != nil {
+		return MoneyValue{}, fmt.Errorf("%s: %w", field, err)
+	}
+	return v, nil
+}
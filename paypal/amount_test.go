@@ -0,0 +1,187 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func usd(value string) *Money {
+	return &Money{Currency: "USD", Value: value}
+}
+
+func TestBuildPurchaseUnitAmount(t *testing.T) {
+	items := []Item{
+		{Name: "Mug", Quantity: "2", UnitAmount: usd("7.50"), Tax: usd("0.60")},
+		{Name: "Sticker", Quantity: "3", UnitAmount: usd("1.00")},
+	}
+	amount, err := BuildPurchaseUnitAmount("USD", items, &AmountBreakdownOptions{Shipping: "4.99", Discount: "2.00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Value != "22.19" || amount.Breakdown.ItemTotal.Value != "18.00" || amount.Breakdown.TaxTotal.Value != "1.20" {
+		t.Fatalf("got value %s, item_total %s, tax_total %s", amount.Value, amount.Breakdown.ItemTotal.Value, amount.Breakdown.TaxTotal.Value)
+	}
+	if err = amount.Validate(items); err != nil {
+		t.Fatalf("built amount doesn't validate: %v", err)
+	}
+
+	_, err = BuildPurchaseUnitAmount("USD", items[1:], &AmountBreakdownOptions{Discount: "3.00"})
+	var mismatch *AmountMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Issue != IssueCannotBeZeroOrNegative {
+		t.Fatalf("zero total: got %v, want %s", err, IssueCannotBeZeroOrNegative)
+	}
+}
+
+func TestPurchaseUnitAmountValidate(t *testing.T) {
+	taxed := []Item{{Name: "Mug", Quantity: "2", UnitAmount: usd("7.50"), Tax: usd("0.60")}}
+	untaxed := []Item{{Name: "Mug", Quantity: "2", UnitAmount: usd("7.50")}}
+
+	tests := []struct {
+		name      string
+		amount    PurchaseUnitAmount
+		items     []Item
+		wantIssue string
+		wantErr   error
+	}{
+		{
+			name:   "no breakdown",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "15.00"},
+		},
+		{
+			name:      "zero value",
+			amount:    PurchaseUnitAmount{Currency: "USD", Value: "0.00"},
+			wantIssue: IssueCannotBeZeroOrNegative,
+		},
+		{
+			name:      "negative value",
+			amount:    PurchaseUnitAmount{Currency: "USD", Value: "-1.00"},
+			wantIssue: IssueCannotBeZeroOrNegative,
+		},
+		{
+			name: "matching breakdown",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "16.20", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"), TaxTotal: usd("1.20"),
+			}},
+			items: taxed,
+		},
+		{
+			name: "value differs from breakdown",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "16.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"), TaxTotal: usd("1.20"),
+			}},
+			items:     taxed,
+			wantIssue: IssueAmountMismatch,
+		},
+		{
+			name: "item_total mismatch",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "14.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("14.00"),
+			}},
+			items:     untaxed,
+			wantIssue: IssueItemTotalMismatch,
+		},
+		{
+			name:      "item_total missing",
+			amount:    PurchaseUnitAmount{Currency: "USD", Value: "15.00"},
+			items:     untaxed,
+			wantIssue: IssueItemTotalRequired,
+		},
+		{
+			name: "tax_total mismatch",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "16.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"), TaxTotal: usd("1.00"),
+			}},
+			items:     taxed,
+			wantIssue: IssueTaxTotalMismatch,
+		},
+		{
+			name: "tax_total missing",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "15.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"),
+			}},
+			items:     taxed,
+			wantIssue: IssueTaxTotalRequired,
+		},
+		{
+			name: "tax_total without taxed items",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "16.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"), TaxTotal: usd("1.00"),
+			}},
+			items:     untaxed,
+			wantIssue: IssueTaxTotalMismatch,
+		},
+		{
+			name: "zero tax_total without taxed items",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "15.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"), TaxTotal: usd("0.00"),
+			}},
+			items: untaxed,
+		},
+		{
+			name: "breakdown currency",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "15.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: &Money{Currency: "EUR", Value: "15.00"},
+			}},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name: "item currency",
+			amount: PurchaseUnitAmount{Currency: "USD", Value: "15.00", Breakdown: &PurchaseUnitAmountBreakdown{
+				ItemTotal: usd("15.00"),
+			}},
+			items:   []Item{{Name: "Mug", Quantity: "2", UnitAmount: &Money{Currency: "EUR", Value: "7.50"}}},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name:    "precision",
+			amount:  PurchaseUnitAmount{Currency: "JPY", Value: "1500.00"},
+			wantErr: ErrAmountPrecision,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.amount.Validate(tt.items)
+			switch {
+			case tt.wantIssue != "":
+				var mismatch *AmountMismatchError
+				if !errors.As(err, &mismatch) || mismatch.Issue != tt.wantIssue {
+					t.Fatalf("got %v, want issue %s", err, tt.wantIssue)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateOrderValidatesPurchaseUnits(t *testing.T) {
+	c, err := NewClient("clientID", "secret", WithAPIBase("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	createOrder := CreateOrder{
+		Intent: OrderIntentCapture,
+		PurchaseUnits: []PurchaseUnit{
+			{ReferenceID: "a", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "15.00"}},
+			{ReferenceID: "b", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "0.00"}},
+		},
+	}
+
+	_, err = c.CreateOrder(context.Background(), createOrder)
+	var mismatch *AmountMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Issue != IssueCannotBeZeroOrNegative {
+		t.Fatalf("got %v, want %s", err, IssueCannotBeZeroOrNegative)
+	}
+
+	_, err = c.CreateOrderWithPaypalRequestID(context.Background(), OrderIntentCapture, []PurchaseUnitRequest{
+		{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "15.00", Breakdown: &PurchaseUnitAmountBreakdown{ItemTotal: usd("14.00")}}},
+	}, nil, nil, "req-1")
+	if !errors.As(err, &mismatch) || mismatch.Issue != IssueAmountMismatch {
+		t.Fatalf("got %v, want %s", err, IssueAmountMismatch)
+	}
+}
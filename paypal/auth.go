@@ -1,9 +1,342 @@
 /**
  * @ClassName auth
- * @Description //TODO 
+ * @Description access token stores and single-flight token refresh
  * @Author liwei
  * @Date 2021/7/7 17:32
  * @Version example V1.0
  **/
 
 package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StoredToken is an access token together with the time it expires
+type StoredToken struct {
+	Token     *TokenResponse `json:"token"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
+
+// TokenStore shares access tokens between Clients, and with a shared backend
+// between processes, so replicas don't each request their own token.
+// Keys are derived from the client ID and API domain, never the secret.
+type TokenStore interface {
+	// Load returns the stored token for key, or nil when there is none
+	Load(ctx context.Context, key string) (*StoredToken, error)
+	// Save stores token for key
+	Save(ctx context.Context, key string, token *StoredToken) error
+}
+
+// MemoryTokenStore keeps tokens in process memory, it is the default TokenStore
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]StoredToken
+}
+
+// TokenRefreshTimeout bounds a token refresh. The refresh is shared by every
+// request waiting for a token, so it doesn't run with any of their contexts.
+var TokenRefreshTimeout = time.Duration(30) * time.Second
+
+// DefaultTokenStore is the TokenStore of clients created by PaypalClient, it
+// lets clients with the same credentials in one process share a token
+var DefaultTokenStore TokenStore = NewMemoryTokenStore()
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]StoredToken)}
+}
+
+// Load returns the token stored for key
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Save stores token for key
+func (s *MemoryTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		s.tokens = make(map[string]StoredToken)
+	}
+	s.tokens[key] = *token
+	return nil
+}
+
+// FileTokenStore keeps tokens in a JSON file readable only by its owner, so
+// processes on one host can share them. Writes replace the file atomically.
+type FileTokenStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileTokenStore returns a FileTokenStore writing to path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Load returns the token stored for key
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Save stores token for key, keeping the tokens of other keys
+func (s *FileTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[key] = *token
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Chmod(0600)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s *FileTokenStore) read() (map[string]StoredToken, error) {
+	tokens := make(map[string]StoredToken)
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err = json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RedisClient is the subset of a Redis client RedisTokenStore needs. Get must
+// return "" and a nil error for a missing key. Adapt go-redis, redigo or a
+// local stand-in for tests to it.
+type RedisClient interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+// RedisTokenStore keeps tokens in Redis, or anything speaking its GET/SET, so
+// every replica of a service shares one token
+type RedisTokenStore struct {
+	Client    RedisClient
+	KeyPrefix string // Defaults to "paypal:token:"
+}
+
+// NewRedisTokenStore returns a RedisTokenStore using client
+func NewRedisTokenStore(client RedisClient) *RedisTokenStore {
+	return &RedisTokenStore{Client: client}
+}
+
+// Load returns the token stored for key
+func (s *RedisTokenStore) Load(ctx context.Context, key string) (*StoredToken, error) {
+	data, err := s.Client.Get(ctx, s.key(key))
+	if err != nil || data == "" {
+		return nil, err
+	}
+	token := &StoredToken{}
+	if err = json.Unmarshal([]byte(data), token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Save stores token for key, expiring it in Redis together with the token
+func (s *RedisTokenStore) Save(ctx context.Context, key string, token *StoredToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.Client.Set(ctx, s.key(key), string(data), ttl)
+}
+
+func (s *RedisTokenStore) key(key string) string {
+	prefix := s.KeyPrefix
+	if prefix == "" {
+		prefix = "paypal:token:"
+	}
+	return prefix + key
+}
+
+// tokenCall is an in-flight token refresh other goroutines wait for
+type tokenCall struct {
+	done  chan struct{}
+	token *TokenResponse
+	err   error
+}
+
+// tokenStoreKey identifies the credentials of c in a TokenStore
+func (c *Client) tokenStoreKey() string {
+	return c.ClientID + "@" + c.Domain
+}
+
+// usable reports whether token can be used for more than margin
+func usable(token *TokenResponse, expiresAt time.Time, margin time.Duration, rejected string) bool {
+	if token == nil || token.Token == "" || token.Token == rejected {
+		return false
+	}
+	return expiresAt.IsZero() || time.Until(expiresAt) >= margin
+}
+
+// accessToken returns a token usable for more than margin. rejected is a token
+// the API refused with 401, it is never returned again. Concurrent callers
+// share a single refresh, which keeps running when ctx of the caller that
+// started it is done.
+func (c *Client) accessToken(ctx context.Context, margin time.Duration, rejected string) (*TokenResponse, error) {
+	c.Lock()
+	if usable(c.Token, c.tokenExpiresAt, margin, rejected) {
+		token := c.Token
+		c.Unlock()
+		return token, nil
+	}
+	call := c.tokenCall
+	leader := call == nil
+	if leader {
+		call = &tokenCall{done: make(chan struct{})}
+		c.tokenCall = call
+	}
+	c.Unlock()
+
+	if leader {
+		go c.runTokenCall(call, margin, rejected)
+	}
+
+	select {
+	case <-call.done:
+		if !leader && call.err == nil && call.token.Token == rejected {
+			// Joined a refresh that didn't know about the rejected token
+			return c.accessToken(ctx, margin, rejected)
+		}
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runTokenCall refreshes the token for call on a context of its own, bounded
+// by TokenRefreshTimeout
+func (c *Client) runTokenCall(call *tokenCall, margin time.Duration, rejected string) {
+	ctx, cancel := context.WithTimeout(context.Background(), TokenRefreshTimeout)
+	defer cancel()
+
+	call.token, call.err = c.refreshToken(ctx, margin, rejected)
+	c.Lock()
+	c.tokenCall = nil
+	c.Unlock()
+	close(call.done)
+}
+
+// refreshToken adopts a usable token from the TokenStore, or requests a new
+// one from PayPal and saves it there
+func (c *Client) refreshToken(ctx context.Context, margin time.Duration, rejected string) (*TokenResponse, error) {
+	store := c.TokenStore
+	if store != nil {
+		stored, err := store.Load(ctx, c.tokenStoreKey())
+		if err == nil && stored != nil && usable(stored.Token, stored.ExpiresAt, margin, rejected) {
+			c.setToken(stored.Token, stored.ExpiresAt)
+			return stored.Token, nil
+		}
+	}
+
+	token, err := c.GetAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token.Token == "" {
+		return nil, errors.New("paypal: no access token returned by /v1/oauth2/token")
+	}
+	return token, nil
+}
+
+func (c *Client) setToken(token *TokenResponse, expiresAt time.Time) {
+	c.Lock()
+	c.Token = token
+	c.tokenExpiresAt = expiresAt
+	c.Unlock()
+}
+
+// StartTokenRefresher refreshes the access token in the background shortly
+// before RequestNewTokenBeforeExpiresIn, so requests never wait for a token.
+// A random jitter spreads the refreshes of replicas sharing a TokenStore.
+// It stops when ctx is done.
+func (c *Client) StartTokenRefresher(ctx context.Context) {
+	go func() {
+		const retryAfterError = 5 * time.Second
+		for {
+			jitter := time.Duration(rand.Int63n(int64(RequestNewTokenBeforeExpiresIn / 2)))
+			margin := RequestNewTokenBeforeExpiresIn + jitter
+
+			wait := retryAfterError
+			if _, err := c.accessToken(ctx, margin, ""); err == nil {
+				c.Lock()
+				expiresAt := c.tokenExpiresAt
+				c.Unlock()
+				if expiresAt.IsZero() {
+					return
+				}
+				wait = time.Until(expiresAt) - margin
+				if wait < retryAfterError {
+					wait = retryAfterError
+				}
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
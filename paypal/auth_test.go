@@ -0,0 +1,273 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer stands in for PayPal, handing out A21AAF1, A21AAF2, ... from
+// /v1/oauth2/token. Requests to other paths are passed to api.
+type tokenServer struct {
+	*httptest.Server
+	tokenRequests int32
+	// block, when set, holds token requests until it is closed
+	block   chan struct{}
+	started chan struct{}
+}
+
+func newTokenServer(t *testing.T, api http.HandlerFunc) *tokenServer {
+	t.Helper()
+	s := &tokenServer{started: make(chan struct{}, 16)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/oauth2/token" {
+			api(w, r)
+			return
+		}
+		n := atomic.AddInt32(&s.tokenRequests, 1)
+		s.started <- struct{}{}
+		if s.block != nil {
+			<-s.block
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"A21AAF%d","token_type":"Bearer","expires_in":32400}`, n)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tokenServer) client(t *testing.T, store TokenStore) *Client {
+	t.Helper()
+	c, err := NewClient("clientID", "secret", WithAPIBase(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TokenStore = store
+	return c
+}
+
+func TestAccessTokenSingleFlight(t *testing.T) {
+	srv := newTokenServer(t, nil)
+	srv.block = make(chan struct{})
+	c := srv.client(t, nil)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := c.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+			if err == nil {
+				tokens[i] = token.Token
+			}
+			errs[i] = err
+		}(i)
+	}
+	<-srv.started
+	time.Sleep(20 * time.Millisecond)
+	close(srv.block)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&srv.tokenRequests); n != 1 {
+		t.Fatalf("%d token requests, want 1", n)
+	}
+	for i := range tokens {
+		if errs[i] != nil || tokens[i] != "A21AAF1" {
+			t.Fatalf("caller %d got %q, %v", i, tokens[i], errs[i])
+		}
+	}
+}
+
+func TestAccessTokenLeaderCancel(t *testing.T) {
+	srv := newTokenServer(t, nil)
+	srv.block = make(chan struct{})
+	c := srv.client(t, nil)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.accessToken(leaderCtx, RequestNewTokenBeforeExpiresIn, "")
+		leaderErr <- err
+	}()
+	<-srv.started
+
+	followerToken := make(chan string, 1)
+	go func() {
+		token, err := c.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+		if err != nil {
+			followerToken <- err.Error()
+			return
+		}
+		followerToken <- token.Token
+	}()
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader got %v, want context.Canceled", err)
+	}
+	close(srv.block)
+	if token := <-followerToken; token != "A21AAF1" {
+		t.Fatalf("follower got %q, want the shared refresh's token", token)
+	}
+	if n := atomic.LoadInt32(&srv.tokenRequests); n != 1 {
+		t.Fatalf("%d token requests, want 1", n)
+	}
+}
+
+func TestFileTokenStoreSharedBetweenClients(t *testing.T) {
+	srv := newTokenServer(t, nil)
+	dir, err := ioutil.TempDir("", "paypal-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens.json")
+
+	first := srv.client(t, NewFileTokenStore(path))
+	second := srv.client(t, NewFileTokenStore(path))
+
+	token, err := first.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := second.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.Token != token.Token {
+		t.Fatalf("second client got %q, want %q from the file", shared.Token, token.Token)
+	}
+	if n := atomic.LoadInt32(&srv.tokenRequests); n != 1 {
+		t.Fatalf("%d token requests, want 1", n)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("token file mode %o, want 600", perm)
+	}
+
+	// A token the API rejected is replaced, not adopted from the file again
+	refreshed, err := second.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, shared.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.Token == shared.Token {
+		t.Fatal("rejected token returned again")
+	}
+	stored, err := NewFileTokenStore(path).Load(context.Background(), second.tokenStoreKey())
+	if err != nil || stored == nil || stored.Token.Token != refreshed.Token {
+		t.Fatalf("file holds %+v, %v; want %q", stored, err, refreshed.Token)
+	}
+}
+
+type fakeRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func (r *fakeRedis) Get(ctx context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.values[key], nil
+}
+
+func (r *fakeRedis) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values == nil {
+		r.values, r.ttls = make(map[string]string), make(map[string]time.Duration)
+	}
+	r.values[key], r.ttls[key] = value, ttl
+	return nil
+}
+
+func TestRedisTokenStoreSharedBetweenClients(t *testing.T) {
+	srv := newTokenServer(t, nil)
+	redis := &fakeRedis{}
+
+	first := srv.client(t, NewRedisTokenStore(redis))
+	second := srv.client(t, NewRedisTokenStore(redis))
+
+	token, err := first.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := second.accessToken(context.Background(), RequestNewTokenBeforeExpiresIn, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.Token != token.Token {
+		t.Fatalf("second client got %q, want %q from redis", shared.Token, token.Token)
+	}
+	if n := atomic.LoadInt32(&srv.tokenRequests); n != 1 {
+		t.Fatalf("%d token requests, want 1", n)
+	}
+
+	key := "paypal:token:clientID@" + srv.URL
+	if !strings.Contains(redis.values[key], token.Token) {
+		t.Fatalf("redis key %q holds %q", key, redis.values[key])
+	}
+	if ttl := redis.ttls[key]; ttl <= 0 || ttl > 32400*time.Second {
+		t.Fatalf("redis ttl %s, want the token lifetime", ttl)
+	}
+	if strings.Contains(redis.values[key], "secret") {
+		t.Fatal("client secret stored in redis")
+	}
+}
+
+func TestSendWithAuthRefreshesRejectedToken(t *testing.T) {
+	var bodies []string
+	var mu sync.Mutex
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") == "Bearer A21AAF1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"name":"AUTHENTICATION_FAILURE","message":"Authentication failed due to invalid authentication credentials"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"5O190127TN364715T","status":"CREATED"}`))
+	})
+	c := srv.client(t, nil)
+
+	req, err := c.NewRequest(context.Background(), "POST", srv.URL+"/v2/checkout/orders", map[string]string{"intent": "CAPTURE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &Order{}
+	if err = c.SendWithAuth(req, order); err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "5O190127TN364715T" {
+		t.Fatalf("order %+v", order)
+	}
+	if n := atomic.LoadInt32(&srv.tokenRequests); n != 2 {
+		t.Fatalf("%d token requests, want 2", n)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Fatalf("request bodies %q, want the same body twice", bodies)
+	}
+	if c.Token.Token != "A21AAF2" {
+		t.Fatalf("client kept token %q", c.Token.Token)
+	}
+}
@@ -1,9 +1,651 @@
 /**
  * @ClassName callback
- * @Description //TODO 
+ * @Description webhook signature verification
  * @Author liwei
  * @Date 2021/7/7 17:33
  * @Version example V1.0
  **/
 
 package paypal
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers PayPal sends with every webhook delivery
+const (
+	HeaderTransmissionID   string = "PAYPAL-TRANSMISSION-ID"
+	HeaderTransmissionTime string = "PAYPAL-TRANSMISSION-TIME"
+	HeaderTransmissionSig  string = "PAYPAL-TRANSMISSION-SIG"
+	HeaderCertURL          string = "PAYPAL-CERT-URL"
+	HeaderAuthAlgo         string = "PAYPAL-AUTH-ALGO"
+
+	// AuthAlgoSHA256WithRSA is the only PAYPAL-AUTH-ALGO PayPal uses today
+	AuthAlgoSHA256WithRSA string = "SHA256withRSA"
+
+	// DefaultCertCacheTTL is how long CachingCertFetcher keeps a certificate
+	DefaultCertCacheTTL = time.Duration(24) * time.Hour
+	// DefaultCertFetchTimeout bounds a certificate download of an HTTPCertFetcher without Client
+	DefaultCertFetchTimeout = time.Duration(10) * time.Second
+)
+
+// certHTTPClient downloads certificates for HTTPCertFetchers without Client,
+// http.DefaultClient has no timeout and a slow cert host would hang the webhook
+var certHTTPClient = &http.Client{Timeout: DefaultCertFetchTimeout}
+
+// WebhookTransmissionTolerance is how far PAYPAL-TRANSMISSION-TIME may be from
+// now, so a captured delivery can't be replayed later. PayPal signs every
+// redelivery anew. Zero disables the check.
+var WebhookTransmissionTolerance = time.Duration(5) * time.Minute
+
+var (
+	// ErrWebhookSignatureInvalid is returned when a webhook delivery was not signed by PayPal
+	ErrWebhookSignatureInvalid = errors.New("paypal: webhook signature verification failed")
+	// ErrWebhookHeadersMissing is returned when one of the PAYPAL-* transmission headers is absent
+	ErrWebhookHeadersMissing = errors.New("paypal: webhook transmission headers missing")
+	// ErrWebhookCertRefused is wrapped by HTTPCertFetcher when a cert URL or the
+	// cert it serves isn't PayPal's. VerifyWebhookSignature never falls back to
+	// remote verification for it.
+	ErrWebhookCertRefused = errors.New("paypal: webhook cert refused")
+	// ErrWebhookCertUnavailable is wrapped by HTTPCertFetcher when the cert
+	// couldn't be downloaded, VerifyWebhookSignature then verifies remotely
+	ErrWebhookCertUnavailable = errors.New("paypal: webhook cert unavailable")
+)
+
+// CertFetcher loads the certificate a webhook was signed with from its PAYPAL-CERT-URL
+type CertFetcher interface {
+	FetchCert(ctx context.Context, certURL string) (*x509.Certificate, error)
+}
+
+// CertFetcherFunc adapts a function to CertFetcher, handy for tests with a locally generated cert
+type CertFetcherFunc func(ctx context.Context, certURL string) (*x509.Certificate, error)
+
+// FetchCert calls f(ctx, certURL)
+func (f CertFetcherFunc) FetchCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	return f(ctx, certURL)
+}
+
+// HTTPCertFetcher downloads PEM certificates over https. Only hosts under
+// paypal.com are accepted unless AllowedHostSuffixes says otherwise, so a
+// forged PAYPAL-CERT-URL cannot point at an attacker's certificate. The
+// certificate must chain to Roots, the system roots when nil, and be issued
+// to an allowed host too. Client defaults to one with DefaultCertFetchTimeout.
+type HTTPCertFetcher struct {
+	Client              *http.Client
+	AllowedHostSuffixes []string
+	Roots               *x509.CertPool
+}
+
+// FetchCert downloads the PEM bundle at certURL and returns its first
+// certificate after verifying its chain and subject
+func (f *HTTPCertFetcher) FetchCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(certURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookCertRefused, err)
+	}
+	if u.Scheme != "https" || !f.allowedHost(u.Hostname()) {
+		return nil, fmt.Errorf("%w: not fetching from %q", ErrWebhookCertRefused, certURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookCertUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: fetching %s: %s", ErrWebhookCertUnavailable, certURL, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookCertUnavailable, err)
+	}
+
+	chain, err := ParseCertChainPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookCertRefused, err)
+	}
+	if err = f.verifyCert(chain); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookCertRefused, err)
+	}
+	return chain[0], nil
+}
+
+func (f *HTTPCertFetcher) httpClient() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return certHTTPClient
+}
+
+// verifyCert checks that chain[0] chains to the roots and is issued to an allowed host
+func (f *HTTPCertFetcher) verifyCert(chain []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := chain[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: f.Roots, Intermediates: intermediates}); err != nil {
+		return err
+	}
+	for _, name := range append([]string{leaf.Subject.CommonName}, leaf.DNSNames...) {
+		if name != "" && f.allowedHost(name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("cert subject %q is not an allowed host", leaf.Subject.CommonName)
+}
+
+// allowedHost reports whether host is one of the allowed suffixes or a
+// subdomain of one, "paypal.com" allows api.paypal.com but not evilpaypal.com
+func (f *HTTPCertFetcher) allowedHost(host string) bool {
+	suffixes := f.AllowedHostSuffixes
+	if len(suffixes) == 0 {
+		suffixes = []string{"paypal.com"}
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, suffix := range suffixes {
+		suffix = strings.TrimPrefix(strings.ToLower(suffix), ".")
+		if suffix == "" {
+			continue
+		}
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCertPEM returns the first certificate of a PEM bundle
+func ParseCertPEM(data []byte) (*x509.Certificate, error) {
+	chain, err := ParseCertChainPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
+
+// ParseCertChainPEM returns the certificates of a PEM bundle, leaf first
+func ParseCertChainPEM(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("paypal: no certificate found in PEM data")
+	}
+	return chain, nil
+}
+
+type cachedCert struct {
+	cert      *x509.Certificate
+	expiresAt time.Time
+}
+
+// CachingCertFetcher keeps certificates returned by Fetcher in memory for TTL,
+// or until they expire, whichever comes first
+type CachingCertFetcher struct {
+	Fetcher CertFetcher
+	TTL     time.Duration
+
+	mu    sync.Mutex
+	certs map[string]cachedCert
+}
+
+// NewCachingCertFetcher wraps fetcher with an in-memory cache using DefaultCertCacheTTL
+func NewCachingCertFetcher(fetcher CertFetcher) *CachingCertFetcher {
+	return &CachingCertFetcher{Fetcher: fetcher, TTL: DefaultCertCacheTTL}
+}
+
+// FetchCert returns the cached certificate for certURL or fetches it
+func (f *CachingCertFetcher) FetchCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	now := time.Now()
+
+	f.mu.Lock()
+	cached, ok := f.certs[certURL]
+	f.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.cert, nil
+	}
+
+	cert, err := f.Fetcher.FetchCert(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := f.TTL
+	if ttl <= 0 {
+		ttl = DefaultCertCacheTTL
+	}
+	expiresAt := now.Add(ttl)
+	if cert.NotAfter.Before(expiresAt) {
+		expiresAt = cert.NotAfter
+	}
+
+	f.mu.Lock()
+	if f.certs == nil {
+		f.certs = make(map[string]cachedCert)
+	}
+	f.certs[certURL] = cachedCert{cert: cert, expiresAt: expiresAt}
+	f.mu.Unlock()
+
+	return cert, nil
+}
+
+// DefaultCertFetcher is used by Client.VerifyWebhookSignature when Client.CertFetcher is nil
+var DefaultCertFetcher CertFetcher = NewCachingCertFetcher(&HTTPCertFetcher{})
+
+// WebhookSignatureMessage builds the string PayPal signs for a webhook delivery:
+// <transmissionId>|<timeStamp>|<webhookId>|<crc32 of body>
+func WebhookSignatureMessage(transmissionID, transmissionTime, webhookID string, body []byte) string {
+	return fmt.Sprintf("%s|%s|%s|%d", transmissionID, transmissionTime, webhookID, crc32.ChecksumIEEE(body))
+}
+
+// VerifyWebhookSignatureWithCert checks the PAYPAL-TRANSMISSION-SIG of a webhook
+// delivery against cert without any network call
+func VerifyWebhookSignatureWithCert(header http.Header, body []byte, webhookID string, cert *x509.Certificate) error {
+	transmissionID := header.Get(HeaderTransmissionID)
+	transmissionTime := header.Get(HeaderTransmissionTime)
+	transmissionSig := header.Get(HeaderTransmissionSig)
+	if transmissionID == "" || transmissionTime == "" || transmissionSig == "" {
+		return ErrWebhookHeadersMissing
+	}
+	if algo := header.Get(HeaderAuthAlgo); algo != "" && algo != AuthAlgoSHA256WithRSA {
+		return fmt.Errorf("paypal: unsupported webhook auth algo %q", algo)
+	}
+	if err := checkTransmissionTime(transmissionTime); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("paypal: webhook cert is not valid at %s", now.Format(time.RFC3339))
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("paypal: webhook cert does not hold an RSA public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(transmissionSig)
+	if err != nil {
+		return ErrWebhookSignatureInvalid
+	}
+	hashed := sha256.Sum256([]byte(WebhookSignatureMessage(transmissionID, transmissionTime, webhookID, body)))
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// checkTransmissionTime rejects deliveries older or newer than WebhookTransmissionTolerance
+func checkTransmissionTime(transmissionTime string) error {
+	if WebhookTransmissionTolerance <= 0 {
+		return nil
+	}
+	sent, err := ParseJSONTime(transmissionTime)
+	if err != nil {
+		return fmt.Errorf("%w: bad %s %q", ErrWebhookSignatureInvalid, HeaderTransmissionTime, transmissionTime)
+	}
+	if age := time.Since(sent.Time()); age > WebhookTransmissionTolerance || age < -WebhookTransmissionTolerance {
+		return fmt.Errorf("%w: transmitted at %s, outside the replay window", ErrWebhookSignatureInvalid, transmissionTime)
+	}
+	return nil
+}
+
+// VerifyWebhookSignature checks that r was sent by PayPal for webhookID. The
+// signature is verified locally with the certificate from PAYPAL-CERT-URL.
+// Only when the certificate couldn't be downloaded, see ErrWebhookCertUnavailable,
+// the remote verify-webhook-signature API is used instead; a refused cert URL
+// fails with ErrWebhookSignatureInvalid. r.Body is restored so it can be
+// decoded afterwards.
+func (c *Client) VerifyWebhookSignature(ctx context.Context, r *http.Request, webhookID string) error {
+	if r.Header.Get(HeaderTransmissionID) == "" || r.Header.Get(HeaderTransmissionSig) == "" ||
+		r.Header.Get(HeaderTransmissionTime) == "" {
+		return ErrWebhookHeadersMissing
+	}
+	if err := checkTransmissionTime(r.Header.Get(HeaderTransmissionTime)); err != nil {
+		return err
+	}
+	body, err := readAndRestoreBody(r)
+	if err != nil {
+		return err
+	}
+
+	fetcher := c.CertFetcher
+	if fetcher == nil {
+		fetcher = DefaultCertFetcher
+	}
+	cert, err := fetcher.FetchCert(ctx, r.Header.Get(HeaderCertURL))
+	if err != nil && !errors.Is(err, ErrWebhookCertUnavailable) {
+		return fmt.Errorf("%w: %v", ErrWebhookSignatureInvalid, err)
+	}
+	if err != nil {
+		resp, remoteErr := c.VerifyWebhookSignatureRemote(ctx, r.Header, body, webhookID)
+		if remoteErr != nil {
+			return fmt.Errorf("paypal: fetching webhook cert: %v; remote verification: %w", err, remoteErr)
+		}
+		if resp.VerificationStatus != VerificationStatusSuccess {
+			return ErrWebhookSignatureInvalid
+		}
+		return nil
+	}
+
+	return VerifyWebhookSignatureWithCert(r.Header, body, webhookID, cert)
+}
+
+// VerifyWebhookSignatureRemote asks PayPal to verify a webhook delivery
+// Endpoint: POST /v1/notifications/verify-webhook-signature
+func (c *Client) VerifyWebhookSignatureRemote(ctx context.Context, header http.Header, body []byte, webhookID string) (*VerifyWebhookResponse, error) {
+	type verifyWebhookSignatureRequest struct {
+		AuthAlgo         string          `json:"auth_algo,omitempty"`
+		CertURL          string          `json:"cert_url,omitempty"`
+		TransmissionID   string          `json:"transmission_id,omitempty"`
+		TransmissionSig  string          `json:"transmission_sig,omitempty"`
+		TransmissionTime string          `json:"transmission_time,omitempty"`
+		WebhookID        string          `json:"webhook_id,omitempty"`
+		WebhookEvent     json.RawMessage `json:"webhook_event,omitempty"`
+	}
+
+	response := &VerifyWebhookResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/verify-webhook-signature"), verifyWebhookSignatureRequest{
+		AuthAlgo:         header.Get(HeaderAuthAlgo),
+		CertURL:          header.Get(HeaderCertURL),
+		TransmissionID:   header.Get(HeaderTransmissionID),
+		TransmissionSig:  header.Get(HeaderTransmissionSig),
+		TransmissionTime: header.Get(HeaderTransmissionTime),
+		WebhookID:        webhookID,
+		WebhookEvent:     json.RawMessage(body),
+	})
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func readAndRestoreBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// DefaultWebhookMaxBodyBytes limits the size of a webhook delivery WebhookHandler reads
+const DefaultWebhookMaxBodyBytes int64 = 1 << 20
+
+// WebhookError lets a webhook callback choose the HTTP status PayPal receives.
+// PayPal redelivers events answered with a non-2xx status, so a callback
+// returning WebhookError with a 2xx status acknowledges the event without retry.
+type WebhookError struct {
+	StatusCode int
+	Err        error
+}
+
+// NewWebhookError wraps err so WebhookHandler answers with statusCode
+func NewWebhookError(statusCode int, err error) error {
+	return &WebhookError{StatusCode: statusCode, Err: err}
+}
+
+func (e *WebhookError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.StatusCode)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *WebhookError) Unwrap() error {
+	return e.Err
+}
+
+// WebhookEventFunc handles a verified webhook event
+type WebhookEventFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler is an http.Handler that verifies webhook deliveries, decodes
+// the event and dispatches it to the callback registered for its event type.
+// A callback returning nil answers 200; any other error answers 500 so PayPal
+// redelivers, unless it is a *WebhookError carrying its own status code.
+type WebhookHandler struct {
+	Client    *Client
+	WebhookID string
+
+	// SkipVerification disables signature checks, only meant for local development
+	SkipVerification bool
+	// MaxBodyBytes defaults to DefaultWebhookMaxBodyBytes
+	MaxBodyBytes int64
+	// Unhandled is called for event types without a callback, when nil those
+	// events are acknowledged with 200 so PayPal stops redelivering them
+	Unhandled WebhookEventFunc
+	// OnError is called with every error that leads to a non-2xx answer
+	OnError func(r *http.Request, err error)
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookEventFunc
+}
+
+// NewWebhookHandler returns a WebhookHandler verifying deliveries for webhookID with c
+func NewWebhookHandler(c *Client, webhookID string) *WebhookHandler {
+	return &WebhookHandler{Client: c, WebhookID: webhookID}
+}
+
+// Handle registers fn for eventType, replacing any previous callback
+func (h *WebhookHandler) Handle(eventType string, fn WebhookEventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string]WebhookEventFunc)
+	}
+	h.handlers[eventType] = fn
+}
+
+// HandleOrder registers fn for an event type whose resource is an order, e.g. CHECKOUT.ORDER.*
+func (h *WebhookHandler) HandleOrder(eventType string, fn func(ctx context.Context, event *WebhookEvent, order *Order) error) {
+	h.Handle(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		order := &Order{}
+		if err := event.DecodeResource(order); err != nil {
+			return NewWebhookError(http.StatusBadRequest, err)
+		}
+		return fn(ctx, event, order)
+	})
+}
+
+// HandleCapture registers fn for an event type whose resource is a capture, e.g. PAYMENT.CAPTURE.COMPLETED
+func (h *WebhookHandler) HandleCapture(eventType string, fn func(ctx context.Context, event *WebhookEvent, capture *CaptureDetailsResponse) error) {
+	h.Handle(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		capture := &CaptureDetailsResponse{}
+		if err := event.DecodeResource(capture); err != nil {
+			return NewWebhookError(http.StatusBadRequest, err)
+		}
+		return fn(ctx, event, capture)
+	})
+}
+
+// HandleRefund registers fn for an event type whose resource is a refund, e.g. PAYMENT.CAPTURE.REFUNDED
+func (h *WebhookHandler) HandleRefund(eventType string, fn func(ctx context.Context, event *WebhookEvent, refund *Refund) error) {
+	h.Handle(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		refund := &Refund{}
+		if err := event.DecodeResource(refund); err != nil {
+			return NewWebhookError(http.StatusBadRequest, err)
+		}
+		return fn(ctx, event, refund)
+	})
+}
+
+// HandleMerchant registers fn for an event type whose resource is a merchant, e.g. MERCHANT.ONBOARDING.COMPLETED
+func (h *WebhookHandler) HandleMerchant(eventType string, fn func(ctx context.Context, event *WebhookEvent, merchant *MerchantEventResource) error) {
+	h.Handle(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		merchant := &MerchantEventResource{}
+		if err := event.DecodeResource(merchant); err != nil {
+			return NewWebhookError(http.StatusBadRequest, err)
+		}
+		return fn(ctx, event, merchant)
+	})
+}
+
+// HandleDispute registers fn for an event type whose resource is a dispute, e.g. CUSTOMER.DISPUTE.CREATED
+func (h *WebhookHandler) HandleDispute(eventType string, fn func(ctx context.Context, event *WebhookEvent, dispute *Dispute) error) {
+	h.Handle(eventType, func(ctx context.Context, event *WebhookEvent) error {
+		dispute := &Dispute{}
+		if err := event.DecodeResource(dispute); err != nil {
+			return NewWebhookError(http.StatusBadRequest, err)
+		}
+		return fn(ctx, event, dispute)
+	})
+}
+
+// OnCheckoutOrderApproved registers fn for CHECKOUT.ORDER.APPROVED
+func (h *WebhookHandler) OnCheckoutOrderApproved(fn func(ctx context.Context, event *WebhookEvent, order *Order) error) {
+	h.HandleOrder(EventCheckoutOrderApproved, fn)
+}
+
+// OnPaymentCaptureCompleted registers fn for PAYMENT.CAPTURE.COMPLETED
+func (h *WebhookHandler) OnPaymentCaptureCompleted(fn func(ctx context.Context, event *WebhookEvent, capture *CaptureDetailsResponse) error) {
+	h.HandleCapture(EventPaymentCaptureCompleted, fn)
+}
+
+// OnPaymentCaptureDenied registers fn for PAYMENT.CAPTURE.DENIED
+func (h *WebhookHandler) OnPaymentCaptureDenied(fn func(ctx context.Context, event *WebhookEvent, capture *CaptureDetailsResponse) error) {
+	h.HandleCapture(EventPaymentCaptureDenied, fn)
+}
+
+// OnPaymentCaptureRefunded registers fn for PAYMENT.CAPTURE.REFUNDED
+func (h *WebhookHandler) OnPaymentCaptureRefunded(fn func(ctx context.Context, event *WebhookEvent, refund *Refund) error) {
+	h.HandleRefund(EventPaymentCaptureRefunded, fn)
+}
+
+// OnMerchantOnboardingCompleted registers fn for MERCHANT.ONBOARDING.COMPLETED
+func (h *WebhookHandler) OnMerchantOnboardingCompleted(fn func(ctx context.Context, event *WebhookEvent, merchant *MerchantEventResource) error) {
+	h.HandleMerchant(EventMerchantOnboardingCompleted, fn)
+}
+
+// OnMerchantPartnerConsentRevoked registers fn for MERCHANT.PARTNER-CONSENT.REVOKED
+func (h *WebhookHandler) OnMerchantPartnerConsentRevoked(fn func(ctx context.Context, event *WebhookEvent, merchant *MerchantEventResource) error) {
+	h.HandleMerchant(EventMerchantPartnerConsentRevoked, fn)
+}
+
+// OnCustomerDisputeCreated registers fn for CUSTOMER.DISPUTE.CREATED
+func (h *WebhookHandler) OnCustomerDisputeCreated(fn func(ctx context.Context, event *WebhookEvent, dispute *Dispute) error) {
+	h.HandleDispute(EventCustomerDisputeCreated, fn)
+}
+
+// OnCustomerDisputeUpdated registers fn for CUSTOMER.DISPUTE.UPDATED
+func (h *WebhookHandler) OnCustomerDisputeUpdated(fn func(ctx context.Context, event *WebhookEvent, dispute *Dispute) error) {
+	h.HandleDispute(EventCustomerDisputeUpdated, fn)
+}
+
+// OnCustomerDisputeResolved registers fn for CUSTOMER.DISPUTE.RESOLVED
+func (h *WebhookHandler) OnCustomerDisputeResolved(fn func(ctx context.Context, event *WebhookEvent, dispute *Dispute) error) {
+	h.HandleDispute(EventCustomerDisputeResolved, fn)
+}
+
+// ServeHTTP verifies, decodes and dispatches a webhook delivery
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, NewWebhookError(http.StatusMethodNotAllowed, fmt.Errorf("paypal: webhook method %s not allowed", r.Method)))
+		return
+	}
+
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultWebhookMaxBodyBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	body, err := readAndRestoreBody(r)
+	if err != nil {
+		h.fail(w, r, NewWebhookError(http.StatusBadRequest, err))
+		return
+	}
+
+	if !h.SkipVerification {
+		if err = h.Client.VerifyWebhookSignature(r.Context(), r, h.WebhookID); err != nil {
+			h.fail(w, r, NewWebhookError(http.StatusBadRequest, err))
+			return
+		}
+	}
+
+	event := &WebhookEvent{}
+	if err = json.Unmarshal(body, event); err != nil {
+		h.fail(w, r, NewWebhookError(http.StatusBadRequest, err))
+		return
+	}
+
+	h.mu.RLock()
+	fn, ok := h.handlers[event.EventType]
+	h.mu.RUnlock()
+	if !ok {
+		fn = h.Unhandled
+	}
+	if fn != nil {
+		if err = fn(r.Context(), event); err != nil {
+			h.fail(w, r, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode := http.StatusInternalServerError
+	var webhookErr *WebhookError
+	if errors.As(err, &webhookErr) && webhookErr.StatusCode != 0 {
+		statusCode = webhookErr.StatusCode
+	}
+	if h.OnError != nil && (statusCode < 200 || statusCode > 299) {
+		h.OnError(r, err)
+	}
+	w.WriteHeader(statusCode)
+}
+
+// DecodeResource unmarshals the event resource into v
+func (e *WebhookEvent) DecodeResource(v interface{}) error {
+	if len(e.Resource) == 0 {
+		return fmt.Errorf("paypal: webhook event %s has no resource", e.ID)
+	}
+	return json.Unmarshal(e.Resource, v)
+}
@@ -0,0 +1,404 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testWebhookID = "WH-TEST-0001"
+
+type testSigner struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+	pem  []byte
+}
+
+func newTestSigner(t *testing.T, commonName string) *testSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSigner{key: key, cert: cert, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (s *testSigner) header(t *testing.T, body []byte, sentAt time.Time) http.Header {
+	t.Helper()
+	transmissionID := "69cd13f0-d67a-11e5-baa3-778b53f4ae55"
+	transmissionTime := sentAt.UTC().Format(time.RFC3339)
+	hashed := sha256.Sum256([]byte(WebhookSignatureMessage(transmissionID, transmissionTime, testWebhookID, body)))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{}
+	header.Set(HeaderTransmissionID, transmissionID)
+	header.Set(HeaderTransmissionTime, transmissionTime)
+	header.Set(HeaderTransmissionSig, base64.StdEncoding.EncodeToString(sig))
+	header.Set(HeaderAuthAlgo, AuthAlgoSHA256WithRSA)
+	header.Set(HeaderCertURL, "https://api.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-a5cafa77")
+	return header
+}
+
+func TestVerifyWebhookSignatureWithCert(t *testing.T) {
+	signer := newTestSigner(t, "messageverificationcerts.paypal.com")
+	body := []byte(`{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"5O190127TN364715T"}}`)
+
+	tests := []struct {
+		name    string
+		body    []byte
+		sentAt  time.Time
+		webhook string
+		wantErr error
+	}{
+		{"valid", body, time.Now(), testWebhookID, nil},
+		{"tampered body", bytes.Replace(body, []byte("5O190127TN364715T"), []byte("5O190127TN364715X"), 1), time.Now(), testWebhookID, ErrWebhookSignatureInvalid},
+		{"other webhook", body, time.Now(), "WH-OTHER", ErrWebhookSignatureInvalid},
+		{"replayed", body, time.Now().Add(-time.Hour), testWebhookID, ErrWebhookSignatureInvalid},
+		{"from the future", body, time.Now().Add(time.Hour), testWebhookID, ErrWebhookSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := signer.header(t, body, tt.sentAt)
+			err := VerifyWebhookSignatureWithCert(header, tt.body, tt.webhook, signer.cert)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookSignatureWithCertOtherKey(t *testing.T) {
+	signer := newTestSigner(t, "messageverificationcerts.paypal.com")
+	forger := newTestSigner(t, "messageverificationcerts.paypal.com")
+	body := []byte(`{"id":"WH-1"}`)
+
+	err := VerifyWebhookSignatureWithCert(forger.header(t, body, time.Now()), body, testWebhookID, signer.cert)
+	if !errors.Is(err, ErrWebhookSignatureInvalid) {
+		t.Fatalf("got %v, want ErrWebhookSignatureInvalid", err)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	signer := newTestSigner(t, "messageverificationcerts.paypal.com")
+	body := []byte(`{"id":"WH-1","event_type":"CHECKOUT.ORDER.APPROVED"}`)
+
+	var remoteCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&remoteCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			w.Write([]byte(`{"access_token":"A21AAF","token_type":"Bearer","expires_in":32400}`))
+		default:
+			w.Write([]byte(`{"verification_status":"SUCCESS"}`))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		body       []byte
+		certURL    string
+		fetcher    CertFetcher
+		wantErr    error
+		wantRemote bool
+	}{
+		{
+			name:    "local cert",
+			body:    body,
+			fetcher: CertFetcherFunc(func(ctx context.Context, certURL string) (*x509.Certificate, error) { return signer.cert, nil }),
+		},
+		{
+			name:    "tampered body",
+			body:    []byte(`{"id":"WH-2","event_type":"CHECKOUT.ORDER.APPROVED"}`),
+			fetcher: CertFetcherFunc(func(ctx context.Context, certURL string) (*x509.Certificate, error) { return signer.cert, nil }),
+			wantErr: ErrWebhookSignatureInvalid,
+		},
+		{
+			name:    "refused cert url",
+			body:    body,
+			certURL: "https://api.evilpaypal.com/cert.pem",
+			fetcher: &HTTPCertFetcher{},
+			wantErr: ErrWebhookSignatureInvalid,
+		},
+		{
+			name: "cert unavailable",
+			body: body,
+			fetcher: CertFetcherFunc(func(ctx context.Context, certURL string) (*x509.Certificate, error) {
+				return nil, ErrWebhookCertUnavailable
+			}),
+			wantRemote: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&remoteCalls, 0)
+			c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.TokenStore = nil
+			c.CertFetcher = tt.fetcher
+
+			header := signer.header(t, body, time.Now())
+			if tt.certURL != "" {
+				header.Set(HeaderCertURL, tt.certURL)
+			}
+			r := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(tt.body))
+			r.Header = header
+
+			err = c.VerifyWebhookSignature(context.Background(), r, testWebhookID)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&remoteCalls) > 0; got != tt.wantRemote {
+				t.Fatalf("remote verification called = %v, want %v", got, tt.wantRemote)
+			}
+		})
+	}
+}
+
+func TestHTTPCertFetcherAllowedHost(t *testing.T) {
+	tests := []struct {
+		suffixes []string
+		host     string
+		want     bool
+	}{
+		{nil, "api.paypal.com", true},
+		{nil, "api.sandbox.paypal.com", true},
+		{nil, "paypal.com", true},
+		{nil, "PAYPAL.COM.", true},
+		{nil, "evilpaypal.com", false},
+		{nil, "paypal.com.evil.io", false},
+		{[]string{".paypal.com"}, "evilpaypal.com", false},
+		{[]string{"paypalobjects.com"}, "www.paypalobjects.com", true},
+		{[]string{"paypalobjects.com"}, "api.paypal.com", false},
+	}
+	for _, tt := range tests {
+		f := &HTTPCertFetcher{AllowedHostSuffixes: tt.suffixes}
+		if got := f.allowedHost(tt.host); got != tt.want {
+			t.Errorf("allowedHost(%q) with %v = %v, want %v", tt.host, tt.suffixes, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPCertFetcherVerifiesChain(t *testing.T) {
+	trusted := newTestSigner(t, "messageverificationcerts.paypal.com")
+	untrusted := newTestSigner(t, "messageverificationcerts.paypal.com")
+	wrongSubject := newTestSigner(t, "attacker.example.com")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(trusted.cert)
+	roots.AddCert(wrongSubject.cert)
+	f := &HTTPCertFetcher{Roots: roots}
+
+	if err := f.verifyCert([]*x509.Certificate{trusted.cert}); err != nil {
+		t.Fatalf("trusted cert: %v", err)
+	}
+	if err := f.verifyCert([]*x509.Certificate{untrusted.cert}); err == nil {
+		t.Fatal("untrusted cert accepted")
+	}
+	if err := f.verifyCert([]*x509.Certificate{wrongSubject.cert}); err == nil {
+		t.Fatal("cert issued to another host accepted")
+	}
+
+	chain, err := ParseCertChainPEM(append(append([]byte{}, trusted.pem...), untrusted.pem...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 || !chain[0].Equal(trusted.cert) {
+		t.Fatalf("ParseCertChainPEM returned %d certs", len(chain))
+	}
+}
+
+func TestHTTPCertFetcherTimeout(t *testing.T) {
+	if timeout := (&HTTPCertFetcher{}).httpClient().Timeout; timeout <= 0 || timeout > time.Minute {
+		t.Fatalf("default cert client timeout %s", timeout)
+	}
+
+	release := make(chan struct{})
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := srv.Client()
+	client.Timeout = 50 * time.Millisecond
+	f := &HTTPCertFetcher{Client: client, AllowedHostSuffixes: []string{"127.0.0.1"}}
+	_, err := f.FetchCert(context.Background(), srv.URL+"/certs/cert.pem")
+	if !errors.Is(err, ErrWebhookCertUnavailable) {
+		t.Fatalf("got %v, want ErrWebhookCertUnavailable", err)
+	}
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	var got []string
+	h := NewWebhookHandler(nil, testWebhookID)
+	h.SkipVerification = true
+	h.OnCheckoutOrderApproved(func(ctx context.Context, event *WebhookEvent, order *Order) error {
+		got = append(got, "order "+order.ID)
+		return nil
+	})
+	h.OnPaymentCaptureCompleted(func(ctx context.Context, event *WebhookEvent, capture *CaptureDetailsResponse) error {
+		got = append(got, "capture "+capture.ID)
+		return nil
+	})
+	h.OnCustomerDisputeCreated(func(ctx context.Context, event *WebhookEvent, dispute *Dispute) error {
+		return errors.New("database unavailable")
+	})
+	h.OnPaymentCaptureRefunded(func(ctx context.Context, event *WebhookEvent, refund *Refund) error {
+		return NewWebhookError(http.StatusAccepted, errors.New("refund of an unknown capture, ignored"))
+	})
+	h.Handle("PAYMENT.CAPTURE.DENIED", func(ctx context.Context, event *WebhookEvent) error {
+		return NewWebhookError(http.StatusConflict, errors.New("capture still processing"))
+	})
+	var errs []error
+	h.OnError = func(r *http.Request, err error) { errs = append(errs, err) }
+	h.MaxBodyBytes = 1024
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		want     int
+		wantGot  string
+		wantErrs int
+	}{
+		{"typed order", "POST", `{"id":"WH-1","event_type":"CHECKOUT.ORDER.APPROVED","resource":{"id":"5O190127TN364715T","status":"APPROVED"}}`, http.StatusOK, "order 5O190127TN364715T", 0},
+		{"typed capture", "POST", `{"id":"WH-2","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"2GG279541U471931P","status":"COMPLETED"}}`, http.StatusOK, "capture 2GG279541U471931P", 0},
+		{"undecodable resource", "POST", `{"id":"WH-3","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":"2GG279541U471931P"}`, http.StatusBadRequest, "", 1},
+		{"missing resource", "POST", `{"id":"WH-4","event_type":"CHECKOUT.ORDER.APPROVED"}`, http.StatusBadRequest, "", 1},
+		{"unknown event", "POST", `{"id":"WH-5","event_type":"BILLING.PLAN.CREATED","resource":{}}`, http.StatusOK, "", 0},
+		{"handler error", "POST", `{"id":"WH-6","event_type":"CUSTOMER.DISPUTE.CREATED","resource":{"dispute_id":"PP-D-27803"}}`, http.StatusInternalServerError, "", 1},
+		{"handler 2xx status", "POST", `{"id":"WH-7","event_type":"PAYMENT.CAPTURE.REFUNDED","resource":{"id":"1JU08902781691411"}}`, http.StatusAccepted, "", 0},
+		{"handler 4xx status", "POST", `{"id":"WH-8","event_type":"PAYMENT.CAPTURE.DENIED","resource":{}}`, http.StatusConflict, "", 1},
+		{"invalid json", "POST", `{"id":`, http.StatusBadRequest, "", 1},
+		{"body too large", "POST", `{"id":"WH-9","summary":"` + strings.Repeat("x", 1024) + `"}`, http.StatusBadRequest, "", 1},
+		{"not a post", "GET", "", http.StatusMethodNotAllowed, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs = nil, nil
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, "/webhooks", strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d", w.Code, tt.want)
+			}
+			if tt.wantGot != "" && (len(got) != 1 || got[0] != tt.wantGot) {
+				t.Fatalf("dispatched %q, want %q", got, tt.wantGot)
+			}
+			if tt.wantGot == "" && len(got) != 0 {
+				t.Fatalf("dispatched %q", got)
+			}
+			if len(errs) != tt.wantErrs {
+				t.Fatalf("OnError called with %v, want %d errors", errs, tt.wantErrs)
+			}
+			if tt.method != "POST" && w.Header().Get("Allow") != "POST" {
+				t.Fatalf("Allow %q", w.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerUnhandled(t *testing.T) {
+	var unhandled []string
+	h := &WebhookHandler{SkipVerification: true}
+	h.Unhandled = func(ctx context.Context, event *WebhookEvent) error {
+		unhandled = append(unhandled, event.EventType)
+		if event.EventType == "BILLING.PLAN.UPDATED" {
+			return errors.New("not ready")
+		}
+		return nil
+	}
+
+	for _, tt := range []struct {
+		eventType string
+		want      int
+	}{
+		{"BILLING.PLAN.CREATED", http.StatusOK},
+		{"BILLING.PLAN.UPDATED", http.StatusInternalServerError},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", strings.NewReader(`{"id":"WH-1","event_type":"`+tt.eventType+`"}`)))
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.eventType, w.Code, tt.want)
+		}
+	}
+	if len(unhandled) != 2 {
+		t.Fatalf("Unhandled called for %q", unhandled)
+	}
+}
+
+func TestWebhookHandlerVerifies(t *testing.T) {
+	signer := newTestSigner(t, "messageverificationcerts.paypal.com")
+	c, err := NewClient("clientID", "secret", WithAPIBase("http://127.0.0.1:0"), WithCertFetcher(CertFetcherFunc(func(ctx context.Context, certURL string) (*x509.Certificate, error) {
+		return signer.cert, nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handled int32
+	h := NewWebhookHandler(c, testWebhookID)
+	h.Handle(EventCheckoutOrderApproved, func(ctx context.Context, event *WebhookEvent) error {
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+
+	body := []byte(`{"id":"WH-1","event_type":"CHECKOUT.ORDER.APPROVED","resource":{"id":"5O190127TN364715T"}}`)
+	forged := []byte(`{"id":"WH-1","event_type":"CHECKOUT.ORDER.APPROVED","resource":{"id":"5O190127TN364715X"}}`)
+	for _, tt := range []struct {
+		name string
+		body []byte
+		want int
+	}{
+		{"signed", body, http.StatusOK},
+		{"forged", forged, http.StatusBadRequest},
+	} {
+		r := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(tt.body))
+		r.Header = signer.header(t, body, time.Now())
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+	if handled != 1 {
+		t.Fatalf("handler called %d times, want 1", handled)
+	}
+}
@@ -15,25 +15,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
+	"net/url"
 	"time"
 )
 
+// PaypalClient creates a Client for the API at doMain, e.g. APIBaseSandBox.
+// See NewClient for more options.
 func PaypalClient(clientID ,secret,doMain string)(*Client,error){
 	if clientID == "" || secret == "" || doMain == "" {
 		return nil,errors.New("ClientID, Secret and APIBase are required to create a Client")
 	}
-	return &Client{
-		Client:   &http.Client{},
-		ClientID: clientID,
-		Secret:   secret,
-		Domain:doMain,
-	},nil
+	return NewClient(clientID, secret, WithAPIBase(doMain))
 
 }
 
+// GetAccessToken requests a new access token from PayPal, sets it on the
+// Client and saves it to the TokenStore
 func (c *Client)GetAccessToken(ctx context.Context)(*TokenResponse,error)  {
 	buf := bytes.NewBuffer([]byte("grant_type=client_credentials"))
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/oauth2/token"), buf)
@@ -45,8 +43,13 @@ func (c *Client)GetAccessToken(ctx context.Context)(*TokenResponse,error)  {
 	err = c.SendWithBasicAuth(req, response)
 	// Set Token fur current Client
 	if response.Token != "" {
-		c.Token = response
-		c.tokenExpiresAt = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+		expiresAt := time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+		c.setToken(response, expiresAt)
+		if c.TokenStore != nil {
+			// A failing store only costs other processes a token request,
+			// so it must not fail the call that already has a token
+			c.TokenStore.Save(ctx, c.tokenStoreKey(), &StoredToken{Token: response, ExpiresAt: expiresAt})
+		}
 	}
 
 	return response, err
@@ -70,12 +73,17 @@ func (c *Client) Send(req *http.Request, v interface{}) error {
 	var (
 		err  error
 		resp *http.Response
-		data []byte
 	)
 
 	// Set default headers
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "en_US")
+	if req.Header.Get("Accept-Language") == "" {
+		if c.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", c.acceptLanguage)
+		} else {
+			req.Header.Set("Accept-Language", "en_US")
+		}
+	}
 
 	// Default values for headers
 	if req.Header.Get("Content-type") == "" {
@@ -84,26 +92,18 @@ func (c *Client) Send(req *http.Request, v interface{}) error {
 	if c.returnRepresentation {
 		req.Header.Set("Prefer", "return=representation")
 	}
-
-	resp, err = c.Client.Do(req)
-	c.log(req, resp)
-
-	if err != nil {
-		return err
+	if c.partnerAttributionID != "" {
+		req.Header.Set("PayPal-Partner-Attribution-Id", c.partnerAttributionID)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		errResp := &ErrorResponse{Response: resp}
-		data, err = ioutil.ReadAll(resp.Body)
-
-		if err == nil && len(data) > 0 {
-			json.Unmarshal(data, errResp)
-		}
 
+	resp, err = c.do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
 		return err
 	}
-	if v == nil {
+	if v == nil || resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
 
@@ -112,29 +112,18 @@ func (c *Client) Send(req *http.Request, v interface{}) error {
 		return nil
 	}
 
-	return json.NewDecoder(resp.Body).Decode(v)
-}
-
-
-func (c *Client) log(r *http.Request, resp *http.Response) {
-	if c.Log != nil {
-		var (
-			reqDump  string
-			respDump []byte
-		)
-
-		if r != nil {
-			reqDump = fmt.Sprintf("%s %s. Data: %s", r.Method, r.URL.String(), r.Form.Encode())
-		}
-		if resp != nil {
-			respDump, _ = httputil.DumpResponse(resp, true)
-		}
-
-		c.Log.Write([]byte(fmt.Sprintf("Request: %s\nResponse: %s\n", reqDump, string(respDump))))
+	// Some calls, e.g. CaptureSubscriptionPayment, may answer 202 without a
+	// body; an empty body with any other status is an error
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err == io.EOF && resp.StatusCode == http.StatusAccepted {
+		return nil
 	}
+	return err
 }
 
 
+// NewRequest constructs a request with payload marshaled to JSON. The body is
+// replayable through req.GetBody, so retries and token refreshes can resend it.
 func (c *Client) NewRequest(ctx context.Context, method, url string, payload interface{}) (*http.Request, error) {
 	var buf io.Reader
 	if payload != nil {
@@ -147,29 +136,56 @@ func (c *Client) NewRequest(ctx context.Context, method, url string, payload int
 	return http.NewRequestWithContext(ctx, method, url, buf)
 }
 
+// setQuery adds the paging parameters that are set to q
+func (p ListParams) setQuery(q url.Values) {
+	if p.Page != "" {
+		q.Set("page", p.Page)
+	}
+	if p.PageSize != "" {
+		q.Set("page_size", p.PageSize)
+	}
+	if p.TotalRequired != "" {
+		q.Set("total_required", p.TotalRequired)
+	}
+}
+
+// withQuery appends q to endpoint unless it is empty
+func withQuery(endpoint string, q url.Values) string {
+	if len(q) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + q.Encode()
+}
+
 // SendWithAuth makes a request to the API and apply OAuth2 header automatically.
-// If the access token soon to be expired or already expired, it will try to get a new one before
-// making the main request
+// If there is no access token yet, or it is soon to be expired or already expired, it will
+// get one from the TokenStore or PayPal before making the main request; concurrent requests
+// share a single refresh. When the API still answers 401 the token is refreshed and the
+// request retried once.
 // client.Token will be updated when changed
 func (c *Client) SendWithAuth(req *http.Request, v interface{}) error {
-	c.Lock()
-	// Note: Here we do not want to `defer c.Unlock()` because we need `c.Send(...)`
-	// to happen outside of the locked section.
-
-	if c.Token != nil {
-		if !c.tokenExpiresAt.IsZero() && c.tokenExpiresAt.Sub(time.Now()) < RequestNewTokenBeforeExpiresIn {
-			// c.Token will be updated in GetAccessToken call
-			if _, err := c.GetAccessToken(req.Context()); err != nil {
-				c.Unlock()
-				return err
-			}
-		}
+	token, err := c.accessToken(req.Context(), RequestNewTokenBeforeExpiresIn, "")
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	err = c.Send(req, v)
+	if !IsStatus(err, http.StatusUnauthorized) || (req.Body != nil && req.GetBody == nil) {
+		return err
+	}
 
-		req.Header.Set("Authorization", "Bearer "+c.Token.Token)
+	// The token was revoked or expired early, never use it again
+	token, err = c.accessToken(req.Context(), RequestNewTokenBeforeExpiresIn, token.Token)
+	if err != nil {
+		return err
+	}
+	if req.GetBody != nil {
+		if req.Body, err = req.GetBody(); err != nil {
+			return err
+		}
 	}
 
-	// Unlock the client mutex before sending the request, this allows multiple requests
-	// to be in progress at the same time.
-	c.Unlock()
+	req.Header.Set("Authorization", "Bearer "+token.Token)
 	return c.Send(req, v)
 }
@@ -0,0 +1,98 @@
+package paypal
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// apiRecorder is a tokenServer recording the API requests it receives and
+// answering them with its responses in order, repeating the last one
+type apiRecorder struct {
+	*tokenServer
+	mu        sync.Mutex
+	requests  []recordedRequest
+	responses []string
+}
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+func newAPIRecorder(t *testing.T, responses ...string) *apiRecorder {
+	t.Helper()
+	rec := &apiRecorder{responses: responses}
+	rec.tokenServer = newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header, Body: body})
+		response := `{}`
+		if n := len(rec.requests); len(rec.responses) >= n {
+			response = rec.responses[n-1]
+		} else if len(rec.responses) > 0 {
+			response = rec.responses[len(rec.responses)-1]
+		}
+		rec.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, response)
+	})
+	return rec
+}
+
+// last returns the latest API request, failing the test when there is none
+func (rec *apiRecorder) last(t *testing.T) recordedRequest {
+	t.Helper()
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.requests) == 0 {
+		t.Fatal("no API request received")
+	}
+	return rec.requests[len(rec.requests)-1]
+}
+
+func TestSendEmptyBody(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		wantEOF bool
+	}{
+		{http.StatusOK, "", true},
+		{http.StatusCreated, "", true},
+		{http.StatusAccepted, "", false},
+		{http.StatusNoContent, "", false},
+		{http.StatusOK, `{"id":"I-BW452GLLEP1G"}`, false},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			io.WriteString(w, tt.body)
+		}))
+		c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := c.NewRequest(context.Background(), "GET", srv.URL+"/v1/billing/subscriptions/I-BW452GLLEP1G", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subscription := &Subscription{}
+		err = c.Send(req, subscription)
+		srv.Close()
+
+		if gotEOF := err == io.EOF; gotEOF != tt.wantEOF || (!tt.wantEOF && err != nil) {
+			t.Errorf("%d with body %q: got %v", tt.status, tt.body, err)
+		}
+		if tt.body != "" && subscription.ID != "I-BW452GLLEP1G" {
+			t.Errorf("%d with body %q: decoded %+v", tt.status, tt.body, subscription)
+		}
+	}
+}
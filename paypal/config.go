@@ -0,0 +1,276 @@
+/**
+ * @ClassName config
+ * @Description YAML configuration of PayPal accounts with environment overrides
+ * @Author liwei
+ * @Date 2026/10/18 17:10
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// lookupEnv is os.LookupEnv, replaceable for tests
+var lookupEnv = os.LookupEnv
+
+// Config holds named PayPal accounts, e.g.
+//
+//	default: shop
+//	accounts:
+//	  shop:
+//	    environment: live
+//	    client_id: AbC...
+//	    secret_file: /run/secrets/paypal-shop
+//	    webhook_id: 1AB23456CD789012E
+//	    brand_name: Example Shop
+//	    return_url: https://shop.example.com/paypal/return
+//	    cancel_url: https://shop.example.com/paypal/cancel
+//
+// Every field of an account can be overridden with PAYPAL_<ACCOUNT>_<FIELD>,
+// e.g. PAYPAL_SHOP_CLIENT_ID or PAYPAL_SHOP_SECRET.
+type Config struct {
+	Default  string                    `yaml:"default,omitempty"`
+	Accounts map[string]*AccountConfig `yaml:"accounts"`
+}
+
+// AccountConfig is one PayPal account of a Config. The secret is taken from
+// the first of SecretFile, SecretEnv and Secret that is set, so it doesn't
+// have to be written into the file. It is only read when a Client is built
+// for the account, a missing secret doesn't affect the other accounts.
+type AccountConfig struct {
+	Name        string `yaml:"-"`
+	Environment string `yaml:"environment,omitempty"` // sandbox or live
+	APIBase     string `yaml:"api_base,omitempty"`    // Overrides Environment, e.g. for a local stand-in
+	ClientID    string `yaml:"client_id"`
+	Secret      string `yaml:"secret,omitempty"`
+	SecretEnv   string `yaml:"secret_env,omitempty"`
+	SecretFile  string `yaml:"secret_file,omitempty"`
+	WebhookID   string `yaml:"webhook_id,omitempty"`
+	BrandName   string `yaml:"brand_name,omitempty"`
+	ReturnURL   string `yaml:"return_url,omitempty"`
+	CancelURL   string `yaml:"cancel_url,omitempty"`
+}
+
+// LoadConfig reads a Config from the YAML file at path, see ParseConfig
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig decodes YAML, applies the environment overrides and validates
+// every account. Secrets are resolved later by AccountConfig.NewClient.
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Accounts) == 0 {
+		return nil, fmt.Errorf("paypal: config has no accounts")
+	}
+
+	for name, account := range cfg.Accounts {
+		if account == nil {
+			account = &AccountConfig{}
+			cfg.Accounts[name] = account
+		}
+		account.Name = name
+		account.applyEnv()
+		if err := account.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Default != "" {
+		if _, ok := cfg.Accounts[cfg.Default]; !ok {
+			return nil, fmt.Errorf("paypal: default account %q is not configured", cfg.Default)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Names returns the account names in alphabetical order
+func (cfg *Config) Names() []string {
+	names := make([]string, 0, len(cfg.Accounts))
+	for name := range cfg.Accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Account returns the named account, or the default account when name is
+// empty. Without a default a config with a single account uses that one.
+func (cfg *Config) Account(name string) (*AccountConfig, error) {
+	if name == "" {
+		name = cfg.Default
+	}
+	if name == "" && len(cfg.Accounts) == 1 {
+		name = cfg.Names()[0]
+	}
+	account, ok := cfg.Accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("paypal: account %q is not configured", name)
+	}
+	return account, nil
+}
+
+// NewClient builds a Client for the named account, see Account
+func (cfg *Config) NewClient(name string, opts ...ClientOption) (*Client, error) {
+	account, err := cfg.Account(name)
+	if err != nil {
+		return nil, err
+	}
+	return account.NewClient(opts...)
+}
+
+// Clients builds a Client for every account, keyed by account name
+func (cfg *Config) Clients(opts ...ClientOption) (map[string]*Client, error) {
+	clients := make(map[string]*Client, len(cfg.Accounts))
+	for name, account := range cfg.Accounts {
+		c, err := account.NewClient(opts...)
+		if err != nil {
+			return nil, err
+		}
+		clients[name] = c
+	}
+	return clients, nil
+}
+
+// NewClient builds a Client for the account, reading its secret from
+// SecretFile or SecretEnv when set. opts are applied after the account's
+// environment.
+func (a *AccountConfig) NewClient(opts ...ClientOption) (*Client, error) {
+	secret, err := a.secret()
+	if err != nil {
+		return nil, err
+	}
+	var base ClientOption
+	if a.APIBase != "" {
+		base = WithAPIBase(a.APIBase)
+	} else {
+		base = WithEnvironment(a.environment())
+	}
+	return NewClient(a.ClientID, secret, append([]ClientOption{base}, opts...)...)
+}
+
+// WebhookHandler returns a WebhookHandler for c verifying deliveries against
+// the account's webhook_id
+func (a *AccountConfig) WebhookHandler(c *Client) (*WebhookHandler, error) {
+	if a.WebhookID == "" {
+		return nil, fmt.Errorf("paypal: account %q: webhook_id is required to verify webhooks", a.Name)
+	}
+	return NewWebhookHandler(c, a.WebhookID), nil
+}
+
+// ApplicationContext returns the brand name and return/cancel URLs of the account for new orders
+func (a *AccountConfig) ApplicationContext() ApplicationContext {
+	return ApplicationContext{
+		BrandName: a.BrandName,
+		ReturnURL: a.ReturnURL,
+		CancelURL: a.CancelURL,
+	}
+}
+
+func (a *AccountConfig) environment() string {
+	if a.Environment == "" {
+		return EnvironmentSandbox
+	}
+	return a.Environment
+}
+
+// applyEnv overrides fields with PAYPAL_<ACCOUNT>_<FIELD> environment variables
+func (a *AccountConfig) applyEnv() {
+	prefix := "PAYPAL_" + envName(a.Name) + "_"
+	fields := []struct {
+		name string
+		dst  *string
+	}{
+		{"ENVIRONMENT", &a.Environment},
+		{"API_BASE", &a.APIBase},
+		{"CLIENT_ID", &a.ClientID},
+		{"SECRET", &a.Secret},
+		{"SECRET_ENV", &a.SecretEnv},
+		{"SECRET_FILE", &a.SecretFile},
+		{"WEBHOOK_ID", &a.WebhookID},
+		{"BRAND_NAME", &a.BrandName},
+		{"RETURN_URL", &a.ReturnURL},
+		{"CANCEL_URL", &a.CancelURL},
+	}
+	for _, f := range fields {
+		if v, ok := lookupEnv(prefix + f.name); ok {
+			*f.dst = v
+			if f.name == "SECRET" {
+				// An explicit secret wins over the indirections from the file
+				a.SecretFile, a.SecretEnv = "", ""
+			}
+		}
+	}
+}
+
+// secret returns the secret from SecretFile, SecretEnv or Secret
+func (a *AccountConfig) secret() (string, error) {
+	secret := a.Secret
+	switch {
+	case a.SecretFile != "":
+		data, err := ioutil.ReadFile(a.SecretFile)
+		if err != nil {
+			return "", fmt.Errorf("paypal: account %q: reading secret: %w", a.Name, err)
+		}
+		secret = strings.TrimSpace(string(data))
+	case a.SecretEnv != "":
+		v, ok := lookupEnv(a.SecretEnv)
+		if !ok {
+			return "", fmt.Errorf("paypal: account %q: secret variable %s is not set", a.Name, a.SecretEnv)
+		}
+		secret = v
+	}
+	if secret == "" {
+		return "", fmt.Errorf("paypal: account %q: secret is empty", a.Name)
+	}
+	return secret, nil
+}
+
+func (a *AccountConfig) validate() error {
+	if a.ClientID == "" {
+		return fmt.Errorf("paypal: account %q: client_id is required", a.Name)
+	}
+	if a.Secret == "" && a.SecretEnv == "" && a.SecretFile == "" {
+		return fmt.Errorf("paypal: account %q: one of secret, secret_env or secret_file is required", a.Name)
+	}
+	if a.APIBase == "" {
+		switch strings.ToLower(a.environment()) {
+		case EnvironmentSandbox, EnvironmentLive:
+		default:
+			return fmt.Errorf("paypal: account %q: unknown environment %q", a.Name, a.Environment)
+		}
+	}
+	return nil
+}
+
+// envName turns an account name into the form used in variable names, "eu-shop" becomes "EU_SHOP"
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,193 @@
+package paypal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withEnv replaces lookupEnv with env for the duration of the test
+func withEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	saved := lookupEnv
+	lookupEnv = func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	t.Cleanup(func() { lookupEnv = saved })
+}
+
+func TestConfigSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paypal-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "paypal-live")
+	if err = ioutil.WriteFile(secretFile, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	withEnv(t, map[string]string{"SHOP_PAYPAL_SECRET": "env-secret"})
+
+	cfg, err := ParseConfig([]byte(`
+default: inline
+accounts:
+  inline:
+    client_id: inline-id
+    secret: inline-secret
+  env:
+    client_id: env-id
+    secret_env: SHOP_PAYPAL_SECRET
+  file:
+    environment: live
+    client_id: file-id
+    secret_file: ` + secretFile + `
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		account string
+		secret  string
+		domain  string
+	}{
+		{"", "inline-secret", APIBaseSandBox},
+		{"env", "env-secret", APIBaseSandBox},
+		{"file", "file-secret", APIBaseLive},
+	}
+	for _, tt := range tests {
+		c, err := cfg.NewClient(tt.account)
+		if err != nil {
+			t.Errorf("account %q: %v", tt.account, err)
+			continue
+		}
+		if c.Secret != tt.secret || c.Domain != tt.domain {
+			t.Errorf("account %q: secret %q at %s, want %q at %s", tt.account, c.Secret, c.Domain, tt.secret, tt.domain)
+		}
+	}
+	if strings.Contains(cfg.Accounts["file"].Secret, "file-secret") {
+		t.Error("secret from file copied into the config")
+	}
+}
+
+func TestConfigBrokenAccountDoesNotAffectOthers(t *testing.T) {
+	withEnv(t, map[string]string{})
+
+	cfg, err := ParseConfig([]byte(`
+default: sandbox
+accounts:
+  sandbox:
+    client_id: sandbox-id
+    secret: sandbox-secret
+  live:
+    environment: live
+    client_id: live-id
+    secret_file: /nonexistent/paypal-live
+  eu:
+    client_id: eu-id
+    secret_env: PAYPAL_EU_CLIENT_SECRET
+`))
+	if err != nil {
+		t.Fatalf("config with an unreadable secret rejected: %v", err)
+	}
+	if _, err = cfg.NewClient(""); err != nil {
+		t.Fatalf("default account: %v", err)
+	}
+	if _, err = cfg.NewClient("live"); err == nil || !strings.Contains(err.Error(), `"live"`) {
+		t.Fatalf("live account: got %v, want a secret error", err)
+	}
+	if _, err = cfg.NewClient("eu"); err == nil || !strings.Contains(err.Error(), "PAYPAL_EU_CLIENT_SECRET") {
+		t.Fatalf("eu account: got %v, want a secret error", err)
+	}
+}
+
+func TestConfigEnvOverrides(t *testing.T) {
+	withEnv(t, map[string]string{
+		"PAYPAL_EU_SHOP_SECRET":     "override-secret",
+		"PAYPAL_EU_SHOP_WEBHOOK_ID": "1AB23456CD789012E",
+		"PAYPAL_EU_SHOP_API_BASE":   "http://127.0.0.1:8080/",
+	})
+
+	cfg, err := ParseConfig([]byte(`
+accounts:
+  eu-shop:
+    client_id: eu-id
+    secret_file: /nonexistent/paypal-eu
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := cfg.Account("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := account.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Secret != "override-secret" || c.Domain != "http://127.0.0.1:8080" {
+		t.Fatalf("secret %q at %s", c.Secret, c.Domain)
+	}
+
+	handler, err := account.WebhookHandler(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handler.WebhookID != "1AB23456CD789012E" || handler.Client != c {
+		t.Fatalf("webhook handler %+v", handler)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	withEnv(t, map[string]string{})
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"no accounts", `default: shop`, "no accounts"},
+		{"missing default", "default: shop\naccounts:\n  sandbox:\n    client_id: id\n    secret: s\n", `default account "shop"`},
+		{"no client id", "accounts:\n  sandbox:\n    secret: s\n", "client_id is required"},
+		{"no secret", "accounts:\n  sandbox:\n    client_id: id\n", "secret, secret_env or secret_file"},
+		{"environment", "accounts:\n  sandbox:\n    client_id: id\n    secret: s\n    environment: prod\n", "unknown environment"},
+		{"unknown field", "accounts:\n  sandbox:\n    client_id: id\n    secret: s\n    sekret: s\n", "sekret"},
+	}
+	for _, tt := range tests {
+		_, err := ParseConfig([]byte(tt.yaml))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error mentioning %q", tt.name, err, tt.want)
+		}
+	}
+
+	cfg, err := ParseConfig([]byte("accounts:\n  a:\n    client_id: id\n    secret: s\n  b:\n    client_id: id\n    secret: s\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cfg.Account(""); err == nil {
+		t.Error("no default among two accounts accepted")
+	}
+	account, _ := cfg.Account("a")
+	if _, err = account.WebhookHandler(nil); err == nil {
+		t.Error("webhook handler without webhook_id accepted")
+	}
+}
+
+func TestLoadExampleConfig(t *testing.T) {
+	withEnv(t, map[string]string{"PAYPAL_SANDBOX_CLIENT_SECRET": "sandbox-secret"})
+
+	cfg, err := LoadConfig(filepath.Join("..", "paypal.example.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := cfg.NewClient("")
+	if err != nil {
+		t.Fatalf("default account without /run/secrets/paypal-live: %v", err)
+	}
+	if c.Domain != APIBaseSandBox || c.Secret != "sandbox-secret" {
+		t.Fatalf("secret %q at %s", c.Secret, c.Domain)
+	}
+}
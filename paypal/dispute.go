@@ -0,0 +1,274 @@
+/**
+ * @ClassName dispute
+ * @Description customer disputes v1 and evidence upload
+ * @Author liwei
+ * @Date 2026/10/18 22:30
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Upload limits of the disputes API
+const (
+	MaxEvidenceFileBytes  = 10 << 20
+	MaxEvidenceTotalBytes = 50 << 20
+)
+
+// evidenceContentTypes are the documents PayPal accepts as evidence, by extension
+var evidenceContentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".png":  "image/png",
+}
+
+// ErrEvidenceTooLarge is returned for evidence files over the upload limits
+var ErrEvidenceTooLarge = errors.New("paypal: evidence exceeds the upload limit")
+
+// EvidenceFile is a document uploaded with ProvideDisputeEvidence or
+// AppealDispute, a PDF, JPEG, GIF or PNG by the extension of Name
+type EvidenceFile struct {
+	Name    string
+	Content []byte
+}
+
+// ListDisputes returns one page of disputes, newest first, params may be nil.
+// Pass the response's NextPageToken in params to get the next page.
+// Endpoint: GET /v1/customer/disputes
+func (c *Client) ListDisputes(ctx context.Context, params *DisputeListParams) (*ListDisputesResponse, error) {
+	response := &ListDisputesResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		if !params.StartTime.IsZero() {
+			q.Set("start_time", params.StartTime.UTC().Format(time.RFC3339))
+		}
+		if params.DisputedTransactionID != "" {
+			q.Set("disputed_transaction_id", params.DisputedTransactionID)
+		}
+		if params.DisputeState != "" {
+			q.Set("dispute_state", params.DisputeState)
+		}
+		if !params.UpdateTimeBefore.IsZero() {
+			q.Set("update_time_before", params.UpdateTimeBefore.UTC().Format(time.RFC3339))
+		}
+		if !params.UpdateTimeAfter.IsZero() {
+			q.Set("update_time_after", params.UpdateTimeAfter.UTC().Format(time.RFC3339))
+		}
+		if params.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(params.PageSize))
+		}
+		if params.NextPageToken != "" {
+			q.Set("next_page_token", params.NextPageToken)
+		}
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/customer/disputes"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// NextPageToken returns the token of the next page, or "" on the last page
+func (r *ListDisputesResponse) NextPageToken() string {
+	for _, link := range r.Links {
+		if link.Rel != "next" {
+			continue
+		}
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			return ""
+		}
+		return u.Query().Get("next_page_token")
+	}
+	return ""
+}
+
+// GetDispute returns a dispute with its transactions, messages and evidence
+// Endpoint: GET /v1/customer/disputes/ID
+func (c *Client) GetDispute(ctx context.Context, disputeID string) (*Dispute, error) {
+	dispute := &Dispute{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/customer/disputes/", disputeID), nil)
+	if err != nil {
+		return dispute, err
+	}
+
+	if err = c.SendWithAuth(req, dispute); err != nil {
+		return dispute, err
+	}
+
+	return dispute, nil
+}
+
+// AcceptDisputeClaim accepts liability, the buyer is refunded and the dispute closed
+// Endpoint: POST /v1/customer/disputes/ID/accept-claim
+func (c *Client) AcceptDisputeClaim(ctx context.Context, disputeID string, acceptClaim AcceptClaimRequest) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/accept-claim", acceptClaim)
+}
+
+// MakeDisputeOffer offers the buyer a refund, return or replacement while the dispute is an INQUIRY
+// Endpoint: POST /v1/customer/disputes/ID/make-offer
+func (c *Client) MakeDisputeOffer(ctx context.Context, disputeID string, offer MakeOfferRequest) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/make-offer", offer)
+}
+
+// EscalateDispute turns an INQUIRY into a claim PayPal decides
+// Endpoint: POST /v1/customer/disputes/ID/escalate
+func (c *Client) EscalateDispute(ctx context.Context, disputeID, note string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/escalate", map[string]string{"note": note})
+}
+
+// SendDisputeMessage posts a message to the buyer
+// Endpoint: POST /v1/customer/disputes/ID/send-message
+func (c *Client) SendDisputeMessage(ctx context.Context, disputeID, message string) (*DisputeActionResponse, error) {
+	return c.disputeAction(ctx, disputeID, "/send-message", map[string]string{"message": message})
+}
+
+// ProvideDisputeEvidence answers a dispute with evidence, files are its documents
+// Endpoint: POST /v1/customer/disputes/ID/provide-evidence
+func (c *Client) ProvideDisputeEvidence(ctx context.Context, disputeID string, evidence ProvideEvidenceRequest, files []EvidenceFile) (*DisputeActionResponse, error) {
+	return c.disputeUpload(ctx, disputeID, "/provide-evidence", evidence, files)
+}
+
+// AppealDispute appeals a dispute resolved against the seller, with new evidence
+// Endpoint: POST /v1/customer/disputes/ID/appeal
+func (c *Client) AppealDispute(ctx context.Context, disputeID string, evidence ProvideEvidenceRequest, files []EvidenceFile) (*DisputeActionResponse, error) {
+	return c.disputeUpload(ctx, disputeID, "/appeal", evidence, files)
+}
+
+func (c *Client) disputeAction(ctx context.Context, disputeID, action string, payload interface{}) (*DisputeActionResponse, error) {
+	response := &DisputeActionResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/customer/disputes/", disputeID, action), payload)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// disputeUpload posts input as the JSON "input" part of a multipart body
+// followed by files, checking the files against the upload limits first
+func (c *Client) disputeUpload(ctx context.Context, disputeID, action string, input interface{}, files []EvidenceFile) (*DisputeActionResponse, error) {
+	response := &DisputeActionResponse{}
+
+	body, contentType, err := evidenceBody(input, files)
+	if err != nil {
+		return response, err
+	}
+
+	// A bytes.Reader body is replayable, so retries and token refreshes can resend it
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/customer/disputes/", disputeID, action), bytes.NewReader(body))
+	if err != nil {
+		return response, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func evidenceBody(input interface{}, files []EvidenceFile) ([]byte, string, error) {
+	var total int
+	for _, file := range files {
+		if _, ok := evidenceContentTypes[strings.ToLower(filepath.Ext(file.Name))]; !ok {
+			return nil, "", fmt.Errorf("paypal: evidence %q must be a PDF, JPEG, GIF or PNG", file.Name)
+		}
+		if len(file.Content) > MaxEvidenceFileBytes {
+			return nil, "", fmt.Errorf("%w: %q has %d bytes, at most %d per file", ErrEvidenceTooLarge, file.Name, len(file.Content), MaxEvidenceFileBytes)
+		}
+		total += len(file.Content)
+	}
+	if total > MaxEvidenceTotalBytes {
+		return nil, "", fmt.Errorf("%w: %d bytes in total, at most %d", ErrEvidenceTooLarge, total, MaxEvidenceTotalBytes)
+	}
+
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="input"`)
+	header.Set("Content-Type", "application/json")
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = part.Write(inputJSON); err != nil {
+		return nil, "", err
+	}
+
+	for i, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i+1, escapeQuotes(filepath.Base(file.Name))))
+		header.Set("Content-Type", evidenceContentTypes[strings.ToLower(filepath.Ext(file.Name))])
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err = part.Write(file.Content); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// IsOpen reports whether the dispute still awaits a resolution. OTHER and
+// unknown statuses are not reported as open.
+func (d *Dispute) IsOpen() bool {
+	switch d.Status {
+	case DisputeStatusOpen, DisputeStatusWaitingForBuyerResponse, DisputeStatusWaitingForSellerResponse, DisputeStatusUnderReview:
+		return true
+	}
+	return false
+}
+
+// NeedsSellerResponse reports whether the seller must answer before SellerResponseDueDate
+func (d *Dispute) NeedsSellerResponse() bool {
+	return d.Status == DisputeStatusWaitingForSellerResponse
+}
@@ -0,0 +1,235 @@
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// evidencePart is one part of a multipart evidence body, read in full
+type evidencePart struct {
+	*multipart.Part
+	data string
+}
+
+// readEvidenceParts parses a multipart evidence body into its parts, in order
+func readEvidenceParts(t *testing.T, contentType string, body []byte) []evidencePart {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("content type %q: %v", contentType, err)
+	}
+	var parts []evidencePart
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, evidencePart{part, string(data)})
+	}
+}
+
+func TestEvidenceBodyLimits(t *testing.T) {
+	file := func(name string, size int) EvidenceFile {
+		return EvidenceFile{Name: name, Content: make([]byte, size)}
+	}
+	fullFiles := func(n int) []EvidenceFile {
+		files := make([]EvidenceFile, n)
+		for i := range files {
+			files[i] = file("tracking.pdf", MaxEvidenceFileBytes)
+		}
+		return files
+	}
+
+	tests := []struct {
+		name     string
+		files    []EvidenceFile
+		valid    bool
+		tooLarge bool
+	}{
+		{"no files", nil, true, false},
+		{"pdf", []EvidenceFile{file("receipt.pdf", 10)}, true, false},
+		{"upper case extension", []EvidenceFile{file("RECEIPT.PDF", 10)}, true, false},
+		{"images", []EvidenceFile{file("a.jpg", 1), file("b.jpeg", 1), file("c.gif", 1), file("d.png", 1)}, true, false},
+		{"word document", []EvidenceFile{file("receipt.docx", 10)}, false, false},
+		{"no extension", []EvidenceFile{file("receipt", 10)}, false, false},
+		{"file at the limit", []EvidenceFile{file("scan.png", MaxEvidenceFileBytes)}, true, false},
+		{"file over the limit", []EvidenceFile{file("scan.png", MaxEvidenceFileBytes+1)}, false, true},
+		{"total at the limit", fullFiles(MaxEvidenceTotalBytes / MaxEvidenceFileBytes), true, false},
+		{"total over the limit", append(fullFiles(MaxEvidenceTotalBytes/MaxEvidenceFileBytes), file("note.pdf", 1)), false, true},
+	}
+	for _, tt := range tests {
+		_, _, err := evidenceBody(ProvideEvidenceRequest{}, tt.files)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: got %v, want valid %t", tt.name, err, tt.valid)
+		}
+		if errors.Is(err, ErrEvidenceTooLarge) != tt.tooLarge {
+			t.Errorf("%s: got %v, want ErrEvidenceTooLarge %t", tt.name, err, tt.tooLarge)
+		}
+	}
+}
+
+func TestEvidenceBodyLayout(t *testing.T) {
+	input := ProvideEvidenceRequest{Evidences: []DisputeEvidence{{EvidenceType: "PROOF_OF_FULFILLMENT", Notes: "Delivered on 2021-07-07"}}}
+	files := []EvidenceFile{
+		{Name: "/tmp/uploads/tracking.PDF", Content: []byte("%PDF-1.4 tracking")},
+		{Name: `receipt "signed".png`, Content: []byte("\x89PNG receipt")},
+	}
+	body, contentType, err := evidenceBody(input, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := readEvidenceParts(t, contentType, body)
+	if len(parts) != 3 {
+		t.Fatalf("%d parts, want input and 2 files", len(parts))
+	}
+	if parts[0].FormName() != "input" || parts[0].FileName() != "" || parts[0].Header.Get("Content-Type") != "application/json" {
+		t.Errorf("first part %q %v", parts[0].FormName(), parts[0].Header)
+	}
+	if want := `{"evidences":[{"evidence_type":"PROOF_OF_FULFILLMENT","notes":"Delivered on 2021-07-07"}]}`; !jsonEqual(t, []byte(parts[0].data), want) {
+		t.Errorf("input %s, want %s", parts[0].data, want)
+	}
+
+	wants := []struct {
+		form, filename, contentType, content string
+	}{
+		{"file1", "tracking.PDF", "application/pdf", "%PDF-1.4 tracking"},
+		{"file2", `receipt "signed".png`, "image/png", "\x89PNG receipt"},
+	}
+	for i, want := range wants {
+		part := parts[i+1]
+		if part.FormName() != want.form || part.FileName() != want.filename || part.Header.Get("Content-Type") != want.contentType {
+			t.Errorf("part %d: %q %q %q, want %q %q %q", i+1, part.FormName(), part.FileName(), part.Header.Get("Content-Type"), want.form, want.filename, want.contentType)
+		}
+		if part.data != want.content {
+			t.Errorf("part %d content %q", i+1, part.data)
+		}
+	}
+}
+
+func TestProvideDisputeEvidenceReplaysBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies [][]byte
+	var contentTypes []string
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		// The first token is revoked
+		if r.Header.Get("Authorization") == "Bearer A21AAF1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_token","error_description":"Token signature verification failed"}`))
+			return
+		}
+		w.Write([]byte(`{"links":[{"href":"https://api-m.sandbox.paypal.com/v1/customer/disputes/PP-D-27803","rel":"self","method":"GET"}]}`))
+	})
+	c := srv.client(t, nil)
+
+	response, err := c.ProvideDisputeEvidence(context.Background(), "PP-D-27803",
+		ProvideEvidenceRequest{Evidences: []DisputeEvidence{{EvidenceType: "PROOF_OF_FULFILLMENT"}}},
+		[]EvidenceFile{{Name: "tracking.pdf", Content: []byte("%PDF-1.4 tracking")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Links) != 1 {
+		t.Errorf("response %+v", response)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("%d uploads, want the rejected one and its replay", len(bodies))
+	}
+	if len(bodies[1]) == 0 || !bytes.Equal(bodies[0], bodies[1]) || contentTypes[0] != contentTypes[1] {
+		t.Fatalf("replayed body differs: %d and %d bytes", len(bodies[0]), len(bodies[1]))
+	}
+	if parts := readEvidenceParts(t, contentTypes[1], bodies[1]); len(parts) != 2 || parts[1].FileName() != "tracking.pdf" {
+		t.Fatalf("replayed body has %d parts", len(parts))
+	}
+}
+
+func TestListDisputes(t *testing.T) {
+	rec := newAPIRecorder(t, `{
+		"items": [{"dispute_id": "PP-D-27803", "status": "WAITING_FOR_SELLER_RESPONSE", "create_time": "2021-07-07T17:53:00.000Z"}],
+		"links": [
+			{"href": "https://api-m.sandbox.paypal.com/v1/customer/disputes?page_size=1", "rel": "self", "method": "GET"},
+			{"href": "https://api-m.sandbox.paypal.com/v1/customer/disputes?page_size=1&next_page_token=DHNgs9wSd0LMn4KkVu3DEjrSYuJT", "rel": "next", "method": "GET"}
+		]
+	}`, `{"items": [], "links": [{"href": "https://api-m.sandbox.paypal.com/v1/customer/disputes?page_size=1", "rel": "self", "method": "GET"}]}`)
+	c := rec.client(t, nil)
+
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.FixedZone("", -7*60*60))
+	page, err := c.ListDisputes(context.Background(), &DisputeListParams{
+		StartTime:    start,
+		DisputeState: DisputeStateRequiredAction,
+		PageSize:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "GET" || req.Path != "/v1/customer/disputes" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Query.Encode(); got != "dispute_state=REQUIRED_ACTION&page_size=1&start_time=2021-07-01T07%3A00%3A00Z" {
+		t.Errorf("query %s", got)
+	}
+	if len(page.Items) != 1 || !page.Items[0].NeedsSellerResponse() {
+		t.Fatalf("items %+v", page.Items)
+	}
+
+	token := page.NextPageToken()
+	if token != "DHNgs9wSd0LMn4KkVu3DEjrSYuJT" {
+		t.Fatalf("NextPageToken %q", token)
+	}
+	page, err = c.ListDisputes(context.Background(), &DisputeListParams{PageSize: 1, NextPageToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Query.Get("next_page_token"); got != token {
+		t.Errorf("next_page_token %q", got)
+	}
+	if token = page.NextPageToken(); token != "" {
+		t.Errorf("NextPageToken on the last page %q", token)
+	}
+}
+
+func TestDisputeStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		open   bool
+		needs  bool
+	}{
+		{DisputeStatusOpen, true, false},
+		{DisputeStatusWaitingForBuyerResponse, true, false},
+		{DisputeStatusWaitingForSellerResponse, true, true},
+		{DisputeStatusUnderReview, true, false},
+		{DisputeStatusResolved, false, false},
+		{DisputeStatusOther, false, false},
+		{"", false, false},
+		{"APPEALABLE", false, false},
+	}
+	for _, tt := range tests {
+		d := &Dispute{Status: tt.status}
+		if d.IsOpen() != tt.open || d.NeedsSellerResponse() != tt.needs {
+			t.Errorf("%q: open %t, needs seller response %t", tt.status, d.IsOpen(), d.NeedsSellerResponse())
+		}
+	}
+}
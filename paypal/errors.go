@@ -0,0 +1,145 @@
+/**
+ * @ClassName errors
+ * @Description typed API errors returned by Client.Send
+ * @Author liwei
+ * @Date 2026/10/18 09:10
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Issue codes PayPal reports in ErrorResponseDetail.Issue
+// Doc: https://developer.paypal.com/docs/api/orders/v2/#errors
+const (
+	IssueOrderAlreadyCaptured      string = "ORDER_ALREADY_CAPTURED"
+	IssueOrderAlreadyAuthorized    string = "ORDER_ALREADY_AUTHORIZED"
+	IssueOrderNotApproved          string = "ORDER_NOT_APPROVED"
+	IssueInstrumentDeclined        string = "INSTRUMENT_DECLINED"
+	IssuePayerActionRequired       string = "PAYER_ACTION_REQUIRED"
+	IssueDuplicateInvoiceID        string = "DUPLICATE_INVOICE_ID"
+	IssueAmountMismatch            string = "AMOUNT_MISMATCH"
+	IssueItemTotalMismatch         string = "ITEM_TOTAL_MISMATCH"
+	IssueItemTotalRequired         string = "ITEM_TOTAL_REQUIRED"
+	IssueTaxTotalMismatch          string = "TAX_TOTAL_MISMATCH"
+	IssueTaxTotalRequired          string = "TAX_TOTAL_REQUIRED"
+	IssueCannotBeZeroOrNegative    string = "CANNOT_BE_ZERO_OR_NEGATIVE"
+	IssueCurrencyNotSupported      string = "CURRENCY_NOT_SUPPORTED"
+	IssueDecimalPrecision          string = "DECIMAL_PRECISION"
+	IssueMaxCaptureCountExceeded   string = "MAX_CAPTURE_COUNT_EXCEEDED"
+	IssueCaptureFullyRefunded      string = "CAPTURE_FULLY_REFUNDED"
+	IssueAuthorizationExpired      string = "AUTHORIZATION_EXPIRED"
+	IssueAuthorizationCaptured     string = "AUTHORIZATION_ALREADY_CAPTURED"
+	IssueDuplicateRequestID        string = "DUPLICATE_REQUEST_ID"
+	IssueInvalidResourceID         string = "INVALID_RESOURCE_ID"
+	IssuePermissionDenied          string = "PERMISSION_DENIED"
+	IssueTransactionRefused        string = "TRANSACTION_REFUSED"
+	IssueUnprocessableEntity       string = "UNPROCESSABLE_ENTITY"
+	IssueRefundAmountExceeded      string = "REFUND_AMOUNT_EXCEEDED"
+	IssueRefundTimeLimitExceeded   string = "REFUND_TIME_LIMIT_EXCEEDED"
+	IssueCannotBeVoided            string = "CANNOT_BE_VOIDED"
+	IssuePreviouslyVoided          string = "PREVIOUSLY_VOIDED"
+	IssueReauthorizationNotAllowed string = "REAUTHORIZATION_NOT_ALLOWED"
+)
+
+// Error implements the error interface, so an *ErrorResponse can be returned from Send
+func (r *ErrorResponse) Error() string {
+	var b strings.Builder
+	if r.Response != nil && r.Response.Request != nil {
+		fmt.Fprintf(&b, "%s %s: ", r.Response.Request.Method, r.Response.Request.URL)
+	}
+	fmt.Fprintf(&b, "%d", r.StatusCode())
+	if r.Name != "" {
+		fmt.Fprintf(&b, " %s", r.Name)
+	}
+	if r.Message != "" {
+		fmt.Fprintf(&b, ": %s", r.Message)
+	}
+	for _, d := range r.Details {
+		fmt.Fprintf(&b, " [%s", d.Issue)
+		if d.Field != "" {
+			fmt.Fprintf(&b, " %s", d.Field)
+		}
+		if d.Description != "" {
+			fmt.Fprintf(&b, ": %s", d.Description)
+		}
+		b.WriteString("]")
+	}
+	if id := r.PaypalDebugID(); id != "" {
+		fmt.Fprintf(&b, " (debug_id %s)", id)
+	}
+	return b.String()
+}
+
+// StatusCode returns the HTTP status code of the failed response, or 0 when unknown
+func (r *ErrorResponse) StatusCode() int {
+	if r.Response == nil {
+		return 0
+	}
+	return r.Response.StatusCode
+}
+
+// PaypalDebugID returns the Paypal-Debug-Id response header, falling back to
+// the debug_id from the body. PayPal support asks for it on every ticket.
+func (r *ErrorResponse) PaypalDebugID() string {
+	if r.Response != nil {
+		if id := r.Response.Header.Get("Paypal-Debug-Id"); id != "" {
+			return id
+		}
+	}
+	return r.DebugID
+}
+
+// HasIssue reports whether one of the error details carries the given issue code
+func (r *ErrorResponse) HasIssue(issue string) bool {
+	for _, d := range r.Details {
+		if d.Issue == issue {
+			return true
+		}
+	}
+	return false
+}
+
+// AsErrorResponse unwraps err into an *ErrorResponse using errors.As
+func AsErrorResponse(err error) (*ErrorResponse, bool) {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp, true
+	}
+	return nil, false
+}
+
+// IsStatus reports whether err is an API error with the given HTTP status code
+func IsStatus(err error, statusCode int) bool {
+	errResp, ok := AsErrorResponse(err)
+	return ok && errResp.StatusCode() == statusCode
+}
+
+// IsNotFound reports whether err is a 404 from the API
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// IsUnprocessable reports whether err is a 422, the status PayPal uses for
+// business rule violations such as ORDER_ALREADY_CAPTURED
+func IsUnprocessable(err error) bool {
+	return IsStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsAuthFailure reports whether err is a 401 or 403 from the API
+func IsAuthFailure(err error) bool {
+	return IsStatus(err, http.StatusUnauthorized) || IsStatus(err, http.StatusForbidden)
+}
+
+// IsIssue reports whether err is an API error carrying the given issue code,
+// e.g. IsIssue(err, IssueOrderAlreadyCaptured)
+func IsIssue(err error, issue string) bool {
+	errResp, ok := AsErrorResponse(err)
+	return ok && errResp.HasIssue(issue)
+}
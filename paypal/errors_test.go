@@ -0,0 +1,102 @@
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendErrorResponse(t *testing.T) {
+	tests := []struct {
+		status         int
+		debugHeader    string
+		body           string
+		notFound       bool
+		unprocessable  bool
+		authFailure    bool
+		issue          string
+		wantDebugID    string
+		wantDetailsLen int
+	}{
+		{
+			status:      http.StatusUnauthorized,
+			body:        `{"error":"invalid_token","error_description":"Token signature verification failed"}`,
+			authFailure: true,
+		},
+		{
+			status:         http.StatusNotFound,
+			debugHeader:    "f0b5b2c5d1d4a",
+			body:           `{"name":"RESOURCE_NOT_FOUND","message":"The specified resource does not exist.","debug_id":"body-debug-id","details":[{"issue":"INVALID_RESOURCE_ID","description":"Specified resource ID does not exist."}]}`,
+			notFound:       true,
+			issue:          IssueInvalidResourceID,
+			wantDebugID:    "f0b5b2c5d1d4a",
+			wantDetailsLen: 1,
+		},
+		{
+			status:         http.StatusUnprocessableEntity,
+			body:           `{"name":"UNPROCESSABLE_ENTITY","message":"The requested action could not be performed.","debug_id":"a7c4a4ba0f1c3","details":[{"issue":"ORDER_ALREADY_CAPTURED","description":"Order already captured."},{"field":"/purchase_units/@reference_id=='default'/amount/value","issue":"DECIMAL_PRECISION"}]}`,
+			unprocessable:  true,
+			issue:          IssueOrderAlreadyCaptured,
+			wantDebugID:    "a7c4a4ba0f1c3",
+			wantDetailsLen: 2,
+		},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tt.debugHeader != "" {
+				w.Header().Set("Paypal-Debug-Id", tt.debugHeader)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := c.NewRequest(context.Background(), "POST", srv.URL+"/v2/checkout/orders/5O190127TN364715T/capture", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = c.Send(req, &Order{})
+		srv.Close()
+
+		wrapped := fmt.Errorf("capture order: %w", err)
+		errResp, ok := AsErrorResponse(wrapped)
+		if !ok {
+			t.Errorf("%d: got %T %v, want *ErrorResponse", tt.status, err, err)
+			continue
+		}
+		if errResp.StatusCode() != tt.status {
+			t.Errorf("%d: StatusCode %d", tt.status, errResp.StatusCode())
+		}
+		if IsNotFound(wrapped) != tt.notFound || IsUnprocessable(wrapped) != tt.unprocessable || IsAuthFailure(wrapped) != tt.authFailure {
+			t.Errorf("%d: IsNotFound %t, IsUnprocessable %t, IsAuthFailure %t", tt.status, IsNotFound(wrapped), IsUnprocessable(wrapped), IsAuthFailure(wrapped))
+		}
+		if tt.issue != "" && !IsIssue(wrapped, tt.issue) {
+			t.Errorf("%d: IsIssue(%s) false for %v", tt.status, tt.issue, err)
+		}
+		if IsIssue(wrapped, IssueInstrumentDeclined) {
+			t.Errorf("%d: IsIssue matched an issue that wasn't sent", tt.status)
+		}
+		if got := errResp.PaypalDebugID(); got != tt.wantDebugID {
+			t.Errorf("%d: PaypalDebugID %q, want %q", tt.status, got, tt.wantDebugID)
+		}
+		if len(errResp.Details) != tt.wantDetailsLen {
+			t.Errorf("%d: %d details, want %d", tt.status, len(errResp.Details), tt.wantDetailsLen)
+		}
+	}
+}
+
+func TestErrorHelpersOnOtherErrors(t *testing.T) {
+	for _, err := range []error{nil, context.Canceled, fmt.Errorf("dial tcp: %w", context.DeadlineExceeded)} {
+		if _, ok := AsErrorResponse(err); ok || IsNotFound(err) || IsUnprocessable(err) || IsAuthFailure(err) || IsIssue(err, IssueOrderAlreadyCaptured) {
+			t.Errorf("%v treated as an API error", err)
+		}
+	}
+	if IsAuthFailure(&ErrorResponse{}) {
+		t.Error("ErrorResponse without a response treated as an auth failure")
+	}
+}
@@ -0,0 +1,397 @@
+/**
+ * @ClassName invoice
+ * @Description invoicing v2 invoices and templates
+ * @Author liwei
+ * @Date 2026/10/18 21:40
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// GenerateNextInvoiceNumber reserves the next number of the account's invoice sequence
+// Endpoint: POST /v2/invoicing/generate-next-invoice-number
+func (c *Client) GenerateNextInvoiceNumber(ctx context.Context) (*InvoiceNumber, error) {
+	number := &InvoiceNumber{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/generate-next-invoice-number"), nil)
+	if err != nil {
+		return number, err
+	}
+
+	if err = c.SendWithAuth(req, number); err != nil {
+		return number, err
+	}
+
+	return number, nil
+}
+
+// CreateDraftInvoice creates a DRAFT invoice. Without Detail.InvoiceNumber
+// the next number is allocated with GenerateNextInvoiceNumber first.
+// Endpoint: POST /v2/invoicing/invoices
+func (c *Client) CreateDraftInvoice(ctx context.Context, invoice Invoice) (*Invoice, error) {
+	if invoice.Detail == nil || invoice.Detail.CurrencyCode == "" {
+		return &Invoice{}, errors.New("paypal: invoice needs Detail.CurrencyCode")
+	}
+	if invoice.Detail.InvoiceNumber == "" {
+		number, err := c.GenerateNextInvoiceNumber(ctx)
+		if err != nil {
+			return &Invoice{}, err
+		}
+		detail := *invoice.Detail
+		detail.InvoiceNumber = number.InvoiceNumber
+		invoice.Detail = &detail
+	}
+
+	return c.CreateDraftInvoiceWithPaypalRequestID(ctx, invoice, "")
+}
+
+// CreateDraftInvoiceWithPaypalRequestID - Use this call to create a draft invoice with idempotency.
+// The invoice is sent as is, so every retry sends the same body: set
+// Detail.InvoiceNumber from one GenerateNextInvoiceNumber call before the
+// first attempt, or leave it empty for PayPal to number the invoice.
+// Endpoint: POST /v2/invoicing/invoices
+func (c *Client) CreateDraftInvoiceWithPaypalRequestID(ctx context.Context, invoice Invoice, requestID string) (*Invoice, error) {
+	response := &Invoice{}
+
+	if invoice.Detail == nil || invoice.Detail.CurrencyCode == "" {
+		return response, errors.New("paypal: invoice needs Detail.CurrencyCode")
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/invoices"), invoice)
+	if err != nil {
+		return response, err
+	}
+
+	// Without it PayPal only answers with a link to the invoice
+	req.Header.Set("Prefer", "return=representation")
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetInvoice returns an invoice
+// Endpoint: GET /v2/invoicing/invoices/ID
+func (c *Client) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error) {
+	invoice := &Invoice{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID), nil)
+	if err != nil {
+		return invoice, err
+	}
+
+	if err = c.SendWithAuth(req, invoice); err != nil {
+		return invoice, err
+	}
+
+	return invoice, nil
+}
+
+// ListInvoices returns one page of invoices, newest first, params may be nil
+// Endpoint: GET /v2/invoicing/invoices
+func (c *Client) ListInvoices(ctx context.Context, params *InvoiceListParameters) (*ListInvoicesResponse, error) {
+	response := &ListInvoicesResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		if params.Fields != "" {
+			q.Set("fields", params.Fields)
+		}
+		params.ListParams.setQuery(q)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/invoices"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// UpdateInvoice replaces an invoice, sendToRecipient emails the recipient about the change
+// Endpoint: PUT /v2/invoicing/invoices/ID
+func (c *Client) UpdateInvoice(ctx context.Context, invoice Invoice, sendToRecipient bool) (*Invoice, error) {
+	response := &Invoice{}
+
+	q := url.Values{}
+	q.Set("send_to_recipient", strconv.FormatBool(sendToRecipient))
+
+	req, err := c.NewRequest(ctx, "PUT", withQuery(fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoice.ID), q), invoice)
+	if err != nil {
+		return response, err
+	}
+
+	req.Header.Set("Prefer", "return=representation")
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// DeleteInvoice deletes a DRAFT or SCHEDULED invoice, cancel sent ones instead
+// Endpoint: DELETE /v2/invoicing/invoices/ID
+func (c *Client) DeleteInvoice(ctx context.Context, invoiceID string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// SendInvoice sends a DRAFT invoice to its recipients, the returned link points
+// to the invoice the recipient sees
+// Endpoint: POST /v2/invoicing/invoices/ID/send
+func (c *Client) SendInvoice(ctx context.Context, invoiceID string, notification InvoiceNotification) (*Link, error) {
+	return c.SendInvoiceWithPaypalRequestID(ctx, invoiceID, notification, "")
+}
+
+// SendInvoiceWithPaypalRequestID - Use this call to send an invoice with idempotency
+// Endpoint: POST /v2/invoicing/invoices/ID/send
+func (c *Client) SendInvoiceWithPaypalRequestID(ctx context.Context, invoiceID string, notification InvoiceNotification, requestID string) (*Link, error) {
+	link := &Link{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/send"), notification)
+	if err != nil {
+		return link, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, link); err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// RemindInvoice emails a reminder about an unpaid invoice
+// Endpoint: POST /v2/invoicing/invoices/ID/remind
+func (c *Client) RemindInvoice(ctx context.Context, invoiceID string, notification InvoiceNotification) error {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/remind"), notification)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// CancelInvoice cancels a sent invoice
+// Endpoint: POST /v2/invoicing/invoices/ID/cancel
+func (c *Client) CancelInvoice(ctx context.Context, invoiceID string, notification InvoiceNotification) error {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/cancel"), notification)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// RecordInvoicePayment marks an invoice paid, fully or partly, by a payment made outside PayPal
+// Endpoint: POST /v2/invoicing/invoices/ID/payments
+func (c *Client) RecordInvoicePayment(ctx context.Context, invoiceID string, payment InvoicePaymentDetail) (*InvoiceRecordResponse, error) {
+	response := &InvoiceRecordResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/payments"), payment)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// DeleteInvoicePayment removes a payment recorded with RecordInvoicePayment
+// Endpoint: DELETE /v2/invoicing/invoices/ID/payments/TRANSACTION_ID
+func (c *Client) DeleteInvoicePayment(ctx context.Context, invoiceID, transactionID string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/payments/", transactionID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// RecordInvoiceRefund marks a paid invoice refunded by a refund made outside PayPal
+// Endpoint: POST /v2/invoicing/invoices/ID/refunds
+func (c *Client) RecordInvoiceRefund(ctx context.Context, invoiceID string, refund InvoiceRefundDetail) (*InvoiceRecordResponse, error) {
+	response := &InvoiceRecordResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/refunds"), refund)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// SearchInvoices returns one page of the invoices matching search, params may be nil
+// Endpoint: POST /v2/invoicing/search-invoices
+func (c *Client) SearchInvoices(ctx context.Context, search InvoiceSearchRequest, params *ListParams) (*ListInvoicesResponse, error) {
+	response := &ListInvoicesResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		params.setQuery(q)
+	}
+
+	req, err := c.NewRequest(ctx, "POST", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/search-invoices"), q), search)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GenerateInvoiceQRCode returns a PNG QR code of the invoice, for printed or
+// in-store invoices
+// Endpoint: POST /v2/invoicing/invoices/ID/generate-qr-code
+func (c *Client) GenerateInvoiceQRCode(ctx context.Context, invoiceID string, qrCode InvoiceQRCodeRequest) ([]byte, error) {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/invoicing/invoices/", invoiceID, "/generate-qr-code"), qrCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// PayPal answers with the base64 encoded image instead of JSON
+	var encoded bytes.Buffer
+	if err = c.SendWithAuth(req, &encoded); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(encoded.Bytes()), nil)))
+}
+
+// CreateInvoiceTemplate creates an invoice template
+// Endpoint: POST /v2/invoicing/templates
+func (c *Client) CreateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	response := &InvoiceTemplate{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/templates"), template)
+	if err != nil {
+		return response, err
+	}
+
+	req.Header.Set("Prefer", "return=representation")
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ListInvoiceTemplates returns the invoice templates of the account, params may be nil
+// Endpoint: GET /v2/invoicing/templates
+func (c *Client) ListInvoiceTemplates(ctx context.Context, params *ListParams) (*ListInvoiceTemplatesResponse, error) {
+	response := &ListInvoiceTemplatesResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		params.setQuery(q)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v2/invoicing/templates"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetInvoiceTemplate returns an invoice template
+// Endpoint: GET /v2/invoicing/templates/ID
+func (c *Client) GetInvoiceTemplate(ctx context.Context, templateID string) (*InvoiceTemplate, error) {
+	template := &InvoiceTemplate{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/templates/", templateID), nil)
+	if err != nil {
+		return template, err
+	}
+
+	if err = c.SendWithAuth(req, template); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+// UpdateInvoiceTemplate replaces an invoice template
+// Endpoint: PUT /v2/invoicing/templates/ID
+func (c *Client) UpdateInvoiceTemplate(ctx context.Context, template InvoiceTemplate) (*InvoiceTemplate, error) {
+	response := &InvoiceTemplate{}
+
+	req, err := c.NewRequest(ctx, "PUT", fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/templates/", template.ID), template)
+	if err != nil {
+		return response, err
+	}
+
+	req.Header.Set("Prefer", "return=representation")
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// DeleteInvoiceTemplate deletes an invoice template
+// Endpoint: DELETE /v2/invoicing/templates/ID
+func (c *Client) DeleteInvoiceTemplate(ctx context.Context, templateID string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s%s%s", c.Domain, "/v2/invoicing/templates/", templateID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// InvoiceItemFromItem converts an order Item into an invoice line. Invoices
+// charge tax by rate, so the amount in item.Tax is replaced by tax, which may
+// be nil. SKU and Category are dropped.
+func InvoiceItemFromItem(item Item, tax *InvoiceTax) InvoiceItem {
+	item.Tax, item.SKU, item.Category = nil, "", ""
+	return InvoiceItem{Item: item, Tax: tax}
+}
+
+// IsPaid reports whether nothing is due on the invoice anymore
+func (i *Invoice) IsPaid() bool {
+	return i.Status == InvoiceStatusPaid || i.Status == InvoiceStatusMarkedAsPaid
+}
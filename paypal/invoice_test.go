@@ -0,0 +1,117 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInvoiceItemJSON(t *testing.T) {
+	item := InvoiceItemFromItem(Item{
+		Name:       "Yoga mat",
+		UnitAmount: &Money{Currency: "USD", Value: "50.00"},
+		Tax:        &Money{Currency: "USD", Value: "3.60"},
+		Quantity:   "2",
+		SKU:        "YM-01",
+		Category:   "PHYSICAL_GOODS",
+	}, &InvoiceTax{Name: "Sales Tax", Percent: "7.25"})
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"Yoga mat","unit_amount":{"currency_code":"USD","value":"50.00"},"quantity":"2","tax":{"name":"Sales Tax","percent":"7.25"}}`
+	if string(data) != want {
+		t.Fatalf("got  %s\nwant %s", data, want)
+	}
+
+	var decoded InvoiceItem
+	if err = json.Unmarshal([]byte(`{
+		"id": "ITEM-5335764681676870V",
+		"name": "Yoga mat",
+		"quantity": "2",
+		"unit_amount": {"currency_code": "USD", "value": "50.00"},
+		"tax": {"name": "Sales Tax", "percent": "7.25", "amount": {"currency_code": "USD", "value": "7.25"}},
+		"unit_of_measure": "QUANTITY"
+	}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.UnitAmount == nil || decoded.UnitAmount.Value != "50.00" || decoded.Item.Tax != nil {
+		t.Fatalf("item %+v", decoded.Item)
+	}
+	if decoded.Tax == nil || decoded.Tax.Amount == nil || decoded.Tax.Amount.Value != "7.25" {
+		t.Fatalf("tax %+v", decoded.Tax)
+	}
+}
+
+func TestInvoicerTaxInfo(t *testing.T) {
+	invoicer := Invoicer{BusinessName: "Example Shop", TaxInfo: TaxInfo{TaxID: "ABcNkWSfb5ICTt73nD3QON1fnnpgNKBy"}}
+	data, err := json.Marshal(invoicer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"tax_id":"ABcNkWSfb5ICTt73nD3QON1fnnpgNKBy","business_name":"Example Shop"}` {
+		t.Fatalf("got %s", data)
+	}
+	var decoded Invoicer
+	if err = json.Unmarshal(data, &decoded); err != nil || decoded.TaxID != invoicer.TaxID {
+		t.Fatalf("decoded %+v, %v", decoded, err)
+	}
+}
+
+func TestCreateDraftInvoiceNumbering(t *testing.T) {
+	var mu sync.Mutex
+	var numbers int
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			w.Write([]byte(`{"access_token":"A21AAF","token_type":"Bearer","expires_in":32400}`))
+		case "/v2/invoicing/generate-next-invoice-number":
+			numbers++
+			fmt.Fprintf(w, `{"invoice_number":"%04d"}`, numbers)
+		case "/v2/invoicing/invoices":
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"INV2-Z56S-5LLA-Q52L-CPZ5","status":"DRAFT"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TokenStore = nil
+
+	invoice := Invoice{Detail: &InvoiceDetail{CurrencyCode: "USD"}}
+	for i := 0; i < 2; i++ {
+		if _, err = c.CreateDraftInvoiceWithPaypalRequestID(context.Background(), invoice, "b1d1f06c-7246-4b8a-9a1b-0c3e2b1b1e3a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if numbers != 0 || len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Fatalf("idempotent retries allocated %d numbers and sent %q", numbers, bodies)
+	}
+
+	if _, err = c.CreateDraftInvoice(context.Background(), invoice); err != nil {
+		t.Fatal(err)
+	}
+	if numbers != 1 || !strings.Contains(bodies[2], `"invoice_number":"0001"`) {
+		t.Fatalf("allocated %d numbers and sent %s", numbers, bodies[2])
+	}
+	if invoice.Detail.InvoiceNumber != "" {
+		t.Fatal("caller's invoice modified")
+	}
+}
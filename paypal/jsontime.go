@@ -0,0 +1,90 @@
+/**
+ * @ClassName jsontime
+ * @Description JSONTime decoding of the timestamp formats PayPal emits
+ * @Author liwei
+ * @Date 2026/10/18 18:40
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jsonTimeLayouts are tried in order by ParseJSONTime. The reporting API writes
+// "2021-07-07T17:53:00+0000", the REST APIs RFC 3339, and invoicing plain dates.
+var jsonTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999-0700",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05.999999999 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseJSONTime parses a timestamp in any format PayPal emits. Timestamps
+// without a zone are taken as UTC.
+func ParseJSONTime(s string) (JSONTime, error) {
+	for _, layout := range jsonTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return JSONTime(t), nil
+		}
+	}
+	return JSONTime{}, fmt.Errorf("paypal: cannot parse time %q", s)
+}
+
+// NewJSONTime converts t to a JSONTime
+func NewJSONTime(t time.Time) JSONTime {
+	return JSONTime(t)
+}
+
+// Time returns t as a time.Time
+func (t JSONTime) Time() time.Time {
+	return time.Time(t)
+}
+
+// IsZero reports whether t is unset
+func (t JSONTime) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// String formats t as RFC 3339
+func (t JSONTime) String() string {
+	return time.Time(t).Format(time.RFC3339)
+}
+
+// MarshalJSON writes t as RFC 3339, which every PayPal API accepts, or null when unset
+func (t JSONTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(time.Time(t).Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON decodes any format accepted by ParseJSONTime, null and "" leave t unset
+func (t *JSONTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = JSONTime{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("paypal: time must be a JSON string: %w", err)
+	}
+	if s == "" {
+		*t = JSONTime{}
+		return nil
+	}
+	parsed, err := ParseJSONTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
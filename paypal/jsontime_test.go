@@ -0,0 +1,112 @@
+package paypal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONTimeLayouts(t *testing.T) {
+	pdt := time.FixedZone("", -7*60*60)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2021-07-07T17:53:00Z", time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)},
+		{"2021-07-07T17:53:00.123Z", time.Date(2021, 7, 7, 17, 53, 0, 123000000, time.UTC)},
+		{"2021-07-07T10:53:00-07:00", time.Date(2021, 7, 7, 10, 53, 0, 0, pdt)},
+		{"2021-07-07T17:53:00+0000", time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)},
+		{"2021-07-07T10:53:00.5-0700", time.Date(2021, 7, 7, 10, 53, 0, 500000000, pdt)},
+		{"2021-07-07T17:53:00", time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)},
+		{"2021-07-07 10:53:00 -0700", time.Date(2021, 7, 7, 10, 53, 0, 0, pdt)},
+		{"2021-07-07 17:53:00 UTC", time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)},
+		{"2021-07-07 17:53:00", time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)},
+		{"2021-07-07", time.Date(2021, 7, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		var got JSONTime
+		if err := json.Unmarshal([]byte(`"`+tt.in+`"`), &got); err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if !got.Time().Equal(tt.want) {
+			t.Errorf("%q decoded as %s, want %s", tt.in, got, tt.want)
+		}
+
+		data, err := json.Marshal(got)
+		if err != nil {
+			t.Errorf("%q: marshal: %v", tt.in, err)
+			continue
+		}
+		var again JSONTime
+		if err = json.Unmarshal(data, &again); err != nil {
+			t.Errorf("%q: round trip of %s: %v", tt.in, data, err)
+			continue
+		}
+		if !again.Time().Equal(tt.want) {
+			t.Errorf("%q round-tripped through %s as %s", tt.in, data, again)
+		}
+	}
+}
+
+func TestJSONTimeUnset(t *testing.T) {
+	for _, in := range []string{`null`, `""`} {
+		got := NewJSONTime(time.Now())
+		if err := json.Unmarshal([]byte(in), &got); err != nil || !got.IsZero() {
+			t.Errorf("%s: got %s, %v", in, got, err)
+		}
+	}
+	data, err := json.Marshal(JSONTime{})
+	if err != nil || string(data) != "null" {
+		t.Errorf("zero JSONTime marshaled as %s, %v", data, err)
+	}
+
+	for _, in := range []string{`"07/07/2021"`, `"yesterday"`, `1625680380`} {
+		var got JSONTime
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("%s accepted as %s", in, got)
+		}
+	}
+}
+
+func TestJSONTimeInResources(t *testing.T) {
+	var dispute Dispute
+	if err := json.Unmarshal([]byte(`{
+		"dispute_id": "PP-D-27803",
+		"create_time": "2021-07-07T17:53:00.000Z",
+		"seller_response_due_date": "2021-07-17T17:53:00+0000"
+	}`), &dispute); err != nil {
+		t.Fatal(err)
+	}
+	if dispute.SellerResponseDueDate == nil || !dispute.SellerResponseDueDate.Time().Equal(time.Date(2021, 7, 17, 17, 53, 0, 0, time.UTC)) {
+		t.Errorf("seller_response_due_date %v", dispute.SellerResponseDueDate)
+	}
+
+	var subscription Subscription
+	if err := json.Unmarshal([]byte(`{
+		"id": "I-BW452GLLEP1G",
+		"start_time": "2021-07-08T00:00:00",
+		"create_time": "2021-07-07T17:53:00Z",
+		"update_time": "2021-07-07T17:53:05Z",
+		"billing_info": {"last_payment": {"amount": {"currency_code": "USD", "value": "10.00"}, "time": "2021-07-08 00:00:05 -0700"}}
+	}`), &subscription); err != nil {
+		t.Fatal(err)
+	}
+	if subscription.StartTime == nil || subscription.StartTime.IsZero() {
+		t.Errorf("start_time %v", subscription.StartTime)
+	}
+	if subscription.CreateTime == nil || !subscription.CreateTime.Time().Equal(time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC)) || subscription.UpdateTime == nil {
+		t.Errorf("create_time %v, update_time %v", subscription.CreateTime, subscription.UpdateTime)
+	}
+	if subscription.BillingInfo == nil || subscription.BillingInfo.LastPayment == nil || subscription.BillingInfo.LastPayment.Time.IsZero() {
+		t.Errorf("billing_info %+v", subscription.BillingInfo)
+	}
+
+	var item PayoutItemResponse
+	if err := json.Unmarshal([]byte(`{"payout_item_id": "8AELMXH8UB2P8", "time_processed": "2021-07-07T17:53:00+0000"}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	if item.TimeProcessed == nil || item.TimeProcessed.IsZero() {
+		t.Errorf("time_processed %v", item.TimeProcessed)
+	}
+}
@@ -0,0 +1,271 @@
+/**
+ * @ClassName logger
+ * @Description structured request logging with redaction of sensitive fields
+ * @Author liwei
+ * @Date 2026/10/18 16:00
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxLogBodyBytes caps the size of request and response bodies written to logs
+const MaxLogBodyBytes = 64 << 10
+
+// Redacted replaces the value of every redacted field in logs
+const Redacted = "[REDACTED]"
+
+// Logger receives one structured record per request attempt, args are
+// alternating keys and values. The method set matches *slog.Logger, so a
+// slog logger can be used as is.
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// DefaultRedactionRules are the fields Redactor hides when it has no rules:
+// card data, tokens, emails, phones and addresses
+var DefaultRedactionRules = []string{
+	"card.number", "card.security_code", "card.expiry",
+	"access_token", "refresh_token", "id_token", "client_secret",
+	"email_address", "email", "payer_email",
+	"phone", "phone_number", "national_number",
+	"address", "shipping_address", "billing_address",
+	"address_line_1", "address_line_2", "line1", "line2",
+	"tax_id", "birth_date",
+}
+
+// Redactor hides sensitive fields of JSON and form encoded bodies. A rule is a
+// field name, optionally prefixed with the names of its parents, e.g.
+// "card.number" hides the number of a card but not of an invoice.
+type Redactor struct {
+	Rules []string
+
+	once  sync.Once
+	rules [][]string
+}
+
+// NewRedactor returns a Redactor using rules, or DefaultRedactionRules when empty
+func NewRedactor(rules ...string) *Redactor {
+	return &Redactor{Rules: rules}
+}
+
+func (r *Redactor) compile() {
+	rules := r.Rules
+	if len(rules) == 0 {
+		rules = DefaultRedactionRules
+	}
+	for _, rule := range rules {
+		r.rules = append(r.rules, strings.Split(rule, "."))
+	}
+}
+
+// matches reports whether the field at path is covered by a rule
+func (r *Redactor) matches(path []string) bool {
+	r.once.Do(r.compile)
+	for _, rule := range r.rules {
+		if len(rule) > len(path) {
+			continue
+		}
+		offset := len(path) - len(rule)
+		matched := true
+		for i := range rule {
+			if rule[i] != path[offset+i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactBody returns body with the redacted fields replaced by Redacted.
+// Bodies that are neither JSON nor form encoded are summarized by size.
+func (r *Redactor) RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			break
+		}
+		for key := range values {
+			if r.matches([]string{key}) {
+				values[key] = []string{Redacted}
+			}
+		}
+		return values.Encode()
+	case mediaType == "" || strings.HasSuffix(mediaType, "json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			break
+		}
+		data, err := json.Marshal(r.redactValue(nil, v))
+		if err != nil {
+			break
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("[%d bytes %s]", len(body), mediaType)
+}
+
+func (r *Redactor) redactValue(path []string, v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			fieldPath := append(path[:len(path):len(path)], key)
+			if r.matches(fieldPath) {
+				value[key] = Redacted
+			} else {
+				value[key] = r.redactValue(fieldPath, field)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = r.redactValue(path, value[i])
+		}
+	}
+	return v
+}
+
+// LoggingMiddleware logs every attempt with method, path, status, latency,
+// PayPal debug id and the bodies redacted by redactor, or NewRedactor() when nil
+func LoggingMiddleware(logger Logger, redactor *Redactor) Middleware {
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			var reqBody []byte
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					reqBody, _ = ioutil.ReadAll(io.LimitReader(body, MaxLogBodyBytes))
+					body.Close()
+				}
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			latency := time.Since(start)
+
+			args := []interface{}{
+				"method", req.Method,
+				"path", req.URL.Path,
+				"latency", latency,
+			}
+			if len(reqBody) > 0 {
+				args = append(args, "request_body", redactor.RedactBody(req.Header.Get("Content-Type"), reqBody))
+			}
+			if resp != nil {
+				args = append(args, "status", resp.StatusCode)
+				if debugID := resp.Header.Get("Paypal-Debug-Id"); debugID != "" {
+					args = append(args, "debug_id", debugID)
+				}
+				if respBody := peekBody(resp); len(respBody) > 0 {
+					args = append(args, "response_body", redactor.RedactBody(resp.Header.Get("Content-Type"), respBody))
+				}
+			}
+
+			if err != nil {
+				args = append(args, "error", logError(err))
+				logger.Error("paypal request", args...)
+			} else {
+				logger.Info("paypal request", args...)
+			}
+			return resp, err
+		}
+	}
+}
+
+// logError describes err without the request URL, which *url.Error and
+// *ErrorResponse include with its unredacted query
+func logError(err error) string {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		// The status, debug id and redacted body are logged already
+		if msg := strings.TrimSpace(errResp.Name + " " + errResp.Message); msg != "" {
+			return msg
+		}
+		return http.StatusText(errResp.StatusCode())
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Op + ": " + urlErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// peekBody reads up to MaxLogBodyBytes of the response body and puts them back
+func peekBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxLogBodyBytes))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// writerLogger writes records as logfmt lines, it backs Client.Log
+type writerLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterLogger returns a Logger writing one key=value line per record to w
+func NewWriterLogger(w io.Writer) Logger {
+	return &writerLogger{w: w}
+}
+
+func (l *writerLogger) Info(msg string, args ...interface{}) {
+	l.write("INFO", msg, args)
+}
+
+func (l *writerLogger) Error(msg string, args ...interface{}) {
+	l.write("ERROR", msg, args)
+}
+
+func (l *writerLogger) write(level, msg string, args []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time=%s level=%s msg=%s", time.Now().Format(time.RFC3339), level, logfmtValue(msg))
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%s", args[i], logfmtValue(fmt.Sprint(args[i+1])))
+	}
+	b.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, b.String())
+}
+
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \"=\n\t") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
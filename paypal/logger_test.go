@@ -0,0 +1,187 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger keeps the records it receives, args keyed by name
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []map[string]interface{}
+}
+
+func (l *recordingLogger) Info(msg string, args ...interface{}) {
+	l.record("INFO", msg, args)
+}
+
+func (l *recordingLogger) Error(msg string, args ...interface{}) {
+	l.record("ERROR", msg, args)
+}
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	record := map[string]interface{}{"level": level, "msg": msg}
+	for i := 0; i+1 < len(args); i += 2 {
+		record[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, record)
+}
+
+func (l *recordingLogger) last(t *testing.T) map[string]interface{} {
+	t.Helper()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.records) == 0 {
+		t.Fatal("nothing logged")
+	}
+	return l.records[len(l.records)-1]
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "card",
+			contentType: "application/json",
+			body:        `{"payment_source":{"card":{"number":"4111111111111111","security_code":"123","expiry":"2027-02","name":"John Doe"}}}`,
+			want:        `{"payment_source":{"card":{"expiry":"[REDACTED]","name":"John Doe","number":"[REDACTED]","security_code":"[REDACTED]"}}}`,
+		},
+		{
+			name:        "number outside a card",
+			contentType: "application/json",
+			body:        `{"detail":{"invoice_number":"INV-0001"},"number":"42"}`,
+			want:        `{"detail":{"invoice_number":"INV-0001"},"number":"42"}`,
+		},
+		{
+			name:        "token response",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"scope":"https://uri.paypal.com/services/invoicing","access_token":"A21AAFEpH4PsADK7qSS7pSRsgzfENtu-Q1ysgEDVDESseMHBYXVJYE8ovjj68elIDy8nF26AwPhfXTIeWAZHSLIsQkSYz9ifg","token_type":"Bearer","expires_in":32400}`,
+			want:        `{"access_token":"[REDACTED]","expires_in":32400,"scope":"https://uri.paypal.com/services/invoicing","token_type":"Bearer"}`,
+		},
+		{
+			name:        "emails and addresses in arrays",
+			contentType: "application/json",
+			body:        `{"purchase_units":[{"shipping":{"address":{"address_line_1":"2211 N First Street","postal_code":"95131"}}}],"payer":{"email_address":"buyer@example.com","name":{"given_name":"John"}}}`,
+			want:        `{"payer":{"email_address":"[REDACTED]","name":{"given_name":"John"}},"purchase_units":[{"shipping":{"address":"[REDACTED]"}}]}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=refresh_token&refresh_token=R23AAH",
+			want:        "grant_type=refresh_token&refresh_token=%5BREDACTED%5D",
+		},
+		{
+			name:        "binary",
+			contentType: "application/pdf",
+			body:        "%PDF-1.4",
+			want:        "[8 bytes application/pdf]",
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			body:        `{"card":{"number":"4111`,
+			want:        "[23 bytes application/json]",
+		},
+	}
+	redactor := NewRedactor()
+	for _, tt := range tests {
+		if got := redactor.RedactBody(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("%s: got  %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+
+	custom := NewRedactor("invoice_number")
+	if got := custom.RedactBody("application/json", []byte(`{"invoice_number":"INV-0001","email_address":"buyer@example.com"}`)); got != `{"email_address":"buyer@example.com","invoice_number":"[REDACTED]"}` {
+		t.Errorf("custom rules: got %s", got)
+	}
+}
+
+func TestLoggingMiddlewareRedacts(t *testing.T) {
+	secrets := []string{"4111111111111111", "security_code\":\"123", "A21AAF", "buyer@example.com", "2211 N First Street", "payee@example.com"}
+
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Paypal-Debug-Id", "f0b5b2c5d1d4a")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"5O190127TN364715T","status":"COMPLETED","payer":{"email_address":"buyer@example.com"},"purchase_units":[{"payee":{"email_address":"payee@example.com"},"shipping":{"address":{"address_line_1":"2211 N First Street"}}}]}`))
+	})
+	logger := &recordingLogger{}
+	c := srv.client(t, nil)
+	c.Logger = logger
+
+	order, err := c.CreateOrderWithPaymentSource(context.Background(), OrderIntentCapture, []PurchaseUnitRequest{
+		{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "15.00"}},
+	}, &PaymentSource{Card: &PaymentSourceCard{Number: "4111111111111111", SecurityCode: "123", Expiry: "2027-02"}}, "7b92603e-77ed-4896-8e78-5dea2050476a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Payer == nil || order.Payer.EmailAddress != "buyer@example.com" {
+		t.Fatalf("logging changed the decoded response: %+v", order.Payer)
+	}
+
+	logger.mu.Lock()
+	records := logger.records
+	logger.mu.Unlock()
+	if len(records) != 2 {
+		t.Fatalf("%d records, want the token and the order request", len(records))
+	}
+	for _, record := range records {
+		data, _ := json.Marshal(record)
+		for _, secret := range secrets {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s logged in %s", secret, data)
+			}
+		}
+	}
+
+	record := logger.last(t)
+	if record["path"] != "/v2/checkout/orders" || record["status"] != http.StatusCreated || record["debug_id"] != "f0b5b2c5d1d4a" {
+		t.Errorf("record %v", record)
+	}
+	for _, key := range []string{"request_body", "response_body"} {
+		if body, _ := record[key].(string); !strings.Contains(body, Redacted) {
+			t.Errorf("%s %q not redacted", key, body)
+		}
+	}
+}
+
+func TestLoggingMiddlewareErrorOmitsQuery(t *testing.T) {
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"name":"INVALID_REQUEST","message":"Request is not well-formed.","details":[{"issue":"INVALID_PARAMETER_VALUE","field":"recipient_email"}]}`))
+	})
+	logger := &recordingLogger{}
+	c := srv.client(t, nil)
+	c.Logger = logger
+
+	query := "?recipient_email=buyer%40example.com"
+	for _, base := range []string{srv.URL, "http://127.0.0.1:1"} {
+		req, err := c.NewRequest(context.Background(), "GET", base+"/v2/invoicing/invoices"+query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = c.Send(req, &struct{}{}); err == nil {
+			t.Fatalf("%s: no error", base)
+		}
+		record := logger.last(t)
+		msg, _ := record["error"].(string)
+		if record["level"] != "ERROR" || msg == "" {
+			t.Fatalf("%s: record %v", base, record)
+		}
+		if strings.Contains(msg, "example.com") || strings.Contains(msg, "recipient_email") {
+			t.Errorf("%s: query logged in %q", base, msg)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/**
+ * @ClassName middleware
+ * @Description middleware chain around every attempt of Client.Send
+ * @Author liwei
+ * @Date 2026/10/18 15:20
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// RoundTripFunc sends one attempt of a request. For a non-2xx answer it
+// returns the response together with the decoded *ErrorResponse, the response
+// body stays readable.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the next RoundTripFunc of the chain. It sees the outgoing
+// request, the response and the decoded error of every attempt, retries
+// included, and may change or replace any of them.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middlewares to the chain of c, the first one registered is the
+// outermost. Register them before sending requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.Lock()
+	defer c.Unlock()
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// chain builds the RoundTripFunc of c from its middlewares and roundTrip. It
+// only takes a read lock, so concurrent requests don't wait for each other.
+func (c *Client) chain() RoundTripFunc {
+	c.RLock()
+	middlewares := c.middlewares
+	logger := c.Logger
+	log := c.Log
+	redactor := c.Redactor
+	c.RUnlock()
+
+	if logger == nil && log != nil {
+		logger = NewWriterLogger(log)
+	}
+
+	next := c.roundTrip
+	if logger != nil {
+		// Innermost, so the record shows the request as it was sent
+		next = LoggingMiddleware(logger, redactor)(next)
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
+	}
+	return next
+}
+
+// roundTrip is the end of the middleware chain, it sends req with c.Client
+// and decodes API errors
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errResp := &ErrorResponse{Response: resp}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+		if err == nil && len(data) > 0 {
+			json.Unmarshal(data, errResp)
+		}
+
+		return resp, errResp
+	}
+
+	return resp, nil
+}
+
+// HeaderMiddleware sets header on every request to the value returned by
+// value, e.g. to propagate tracing ids. Empty values are not set.
+func HeaderMiddleware(header string, value func(req *http.Request) string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if v := value(req); v != "" {
+				req.Header.Set(header, v)
+			}
+			return next(req)
+		}
+	}
+}
+
+// RequestIDMiddleware sets a PayPal-Request-Id on POST and PATCH requests
+// that don't have one, which makes them idempotent and lets RetryPolicy retry
+// them. The id is kept across the retries of one Send. generate defaults to
+// NewRequestID, a generate error fails the request.
+func RequestIDMiddleware(generate func() (string, error)) Middleware {
+	if generate == nil {
+		generate = NewRequestID
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if (req.Method == "POST" || req.Method == "PATCH") && req.Header.Get("PayPal-Request-Id") == "" &&
+				req.URL.Path != "/v1/oauth2/token" {
+				requestID, err := generate()
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("PayPal-Request-Id", requestID)
+			}
+			return next(req)
+		}
+	}
+}
+
+// TimingMiddleware calls observe after every attempt with its outcome and
+// duration, e.g. to feed latency metrics
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			observe(req, resp, err, time.Since(start))
+			return resp, err
+		}
+	}
+}
+
+// NewRequestID returns a random version 4 UUID, suitable as PayPal-Request-Id
+func NewRequestID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("paypal: generating request id: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
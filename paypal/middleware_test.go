@@ -0,0 +1,140 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewRequestID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id, err := NewRequestID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !uuid.MatchString(id) || seen[id] {
+			t.Fatalf("bad or repeated request id %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get("PayPal-Request-Id"))
+		attempt := len(ids)
+		mu.Unlock()
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Use(RequestIDMiddleware(nil))
+
+	req, err := c.NewRequest(context.Background(), "POST", srv.URL+"/v2/checkout/orders", map[string]string{"intent": "CAPTURE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Send(req, &struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Fatalf("request ids %q, want one id kept across the retry", ids)
+	}
+
+	failing := errors.New("entropy exhausted")
+	c, err = NewClient("clientID", "secret", WithAPIBase(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Use(RequestIDMiddleware(func() (string, error) { return "", failing }))
+	req, err = c.NewRequest(context.Background(), "POST", srv.URL+"/v2/checkout/orders", map[string]string{"intent": "CAPTURE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Send(req, &struct{}{}); !errors.Is(err, failing) {
+		t.Fatalf("got %v, want the generate error", err)
+	}
+}
+
+func TestMiddlewareOrderAndConcurrency(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both requests must be inside the chain at the same time to get past the barrier
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return next(req)
+			}
+		}
+	}
+	c.Use(record("outer"), func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			barrier.Done()
+			barrier.Wait()
+			return next(req)
+		}
+	}, record("inner"))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := c.NewRequest(context.Background(), "GET", srv.URL+"/v2/checkout/orders/5O190127TN364715T", nil)
+			if err == nil {
+				err = c.Send(req, &struct{}{})
+			}
+			errs <- err
+		}()
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrent requests were serialized")
+	}
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(order) != 4 || order[0] != "outer" || order[1] != "outer" || order[2] != "inner" || order[3] != "inner" {
+		t.Fatalf("middleware order %q", order)
+	}
+}
@@ -0,0 +1,373 @@
+/**
+ * @ClassName money
+ * @Description exact decimal money values with ISO-4217 minor units
+ * @Author liwei
+ * @Date 2026/10/18 11:50
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownCurrency is returned for currency codes missing from the minor unit table
+	ErrUnknownCurrency = errors.New("paypal: unknown currency")
+	// ErrCurrencyMismatch is returned when combining amounts of different currencies
+	ErrCurrencyMismatch = errors.New("paypal: currency mismatch")
+	// ErrInvalidAmount is returned for values that are not plain decimal numbers
+	ErrInvalidAmount = errors.New("paypal: invalid amount")
+	// ErrAmountPrecision is returned for values with more decimals than the currency allows,
+	// e.g. "15.00" JPY
+	ErrAmountPrecision = errors.New("paypal: too many decimal places for currency")
+	// ErrAmountOverflow is returned when an amount doesn't fit in 64 bits of minor units
+	ErrAmountOverflow = errors.New("paypal: amount overflow")
+)
+
+// currencyMinorUnits maps ISO-4217 codes to the number of decimals PayPal accepts.
+// PayPal deviates from ISO-4217 for HUF and TWD, which it only takes as whole numbers.
+// Doc: https://developer.paypal.com/docs/reports/reference/paypal-supported-currencies/
+var currencyMinorUnits = map[string]int{
+	"AED": 2, "ARS": 2, "AUD": 2, "BGN": 2, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2,
+	"COP": 2, "CZK": 2, "DKK": 2, "EGP": 2, "EUR": 2, "GBP": 2, "HKD": 2, "IDR": 2,
+	"ILS": 2, "INR": 2, "MAD": 2, "MXN": 2, "MYR": 2, "NOK": 2, "NZD": 2, "PEN": 2,
+	"PHP": 2, "PLN": 2, "RON": 2, "RUB": 2, "SAR": 2, "SEK": 2, "SGD": 2, "THB": 2,
+	"TRY": 2, "UAH": 2, "USD": 2, "ZAR": 2,
+
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "HUF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "TWD": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyMinorUnits returns the number of decimals PayPal accepts for currency
+func CurrencyMinorUnits(currency string) (int, error) {
+	digits, ok := currencyMinorUnits[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return digits, nil
+}
+
+// MoneyValue is an exact amount of a currency, stored as an integer number of
+// minor units (cents for USD, yen for JPY). The zero value has no currency and
+// is only useful as the result of a failed operation.
+type MoneyValue struct {
+	currency string
+	minor    int64
+}
+
+// NewMoneyValue returns minor units of currency, e.g. NewMoneyValue("USD", 1500) is 15.00 USD
+func NewMoneyValue(currency string, minor int64) (MoneyValue, error) {
+	if _, err := CurrencyMinorUnits(currency); err != nil {
+		return MoneyValue{}, err
+	}
+	return MoneyValue{currency: currency, minor: minor}, nil
+}
+
+// ParseMoney parses a value in PayPal's string format, e.g. ParseMoney("USD", "15.00").
+// Fewer decimals than the currency has are accepted, more are an ErrAmountPrecision.
+func ParseMoney(currency, value string) (MoneyValue, error) {
+	digits, err := CurrencyMinorUnits(currency)
+	if err != nil {
+		return MoneyValue{}, err
+	}
+
+	s := value
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+		if frac == "" {
+			return MoneyValue{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+		}
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return MoneyValue{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if len(frac) > digits {
+		return MoneyValue{}, fmt.Errorf("%w: %q has %d, %s allows %d", ErrAmountPrecision, value, len(frac), currency, digits)
+	}
+
+	frac += strings.Repeat("0", digits-len(frac))
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return MoneyValue{}, fmt.Errorf("%w: %q", ErrAmountOverflow, value)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return MoneyValue{currency: currency, minor: minor}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on error, for constants in code
+func MustParseMoney(currency, value string) MoneyValue {
+	m, err := ParseMoney(currency, value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Currency returns the ISO-4217 currency code
+func (m MoneyValue) Currency() string {
+	return m.currency
+}
+
+// MinorUnits returns the amount in minor units, e.g. 1500 for 15.00 USD
+func (m MoneyValue) MinorUnits() int64 {
+	return m.minor
+}
+
+// String formats the amount the way PayPal expects it, e.g. "15.00" for USD and "15" for JPY
+func (m MoneyValue) String() string {
+	digits := currencyMinorUnits[m.currency]
+
+	sign := ""
+	abs := uint64(m.minor)
+	if m.minor < 0 {
+		sign = "-"
+		abs = uint64(-m.minor)
+	}
+	s := strconv.FormatUint(abs, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// IsZero reports whether the amount is zero
+func (m MoneyValue) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m MoneyValue) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Cmp compares m and o, returning -1, 0 or +1
+func (m MoneyValue) Cmp(o MoneyValue) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.minor < o.minor:
+		return -1, nil
+	case m.minor > o.minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal reports whether m and o have the same currency and amount
+func (m MoneyValue) Equal(o MoneyValue) bool {
+	return m == o
+}
+
+// Add returns m + o
+func (m MoneyValue) Add(o MoneyValue) (MoneyValue, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return MoneyValue{}, err
+	}
+	sum := m.minor + o.minor
+	if (o.minor > 0 && sum < m.minor) || (o.minor < 0 && sum > m.minor) {
+		return MoneyValue{}, ErrAmountOverflow
+	}
+	return MoneyValue{currency: m.currency, minor: sum}, nil
+}
+
+// Sub returns m - o
+func (m MoneyValue) Sub(o MoneyValue) (MoneyValue, error) {
+	if o.minor == math.MinInt64 {
+		return MoneyValue{}, ErrAmountOverflow
+	}
+	return m.Add(MoneyValue{currency: o.currency, minor: -o.minor})
+}
+
+// Mul returns m * n, e.g. a unit amount times an item quantity
+func (m MoneyValue) Mul(n int64) (MoneyValue, error) {
+	if m.minor == 0 || n == 0 {
+		return MoneyValue{currency: m.currency}, nil
+	}
+	product := m.minor * n
+	if product/n != m.minor || (m.minor == -1 && n == math.MinInt64) || (n == -1 && m.minor == math.MinInt64) {
+		return MoneyValue{}, ErrAmountOverflow
+	}
+	return MoneyValue{currency: m.currency, minor: product}, nil
+}
+
+// MulDecimal returns m * factor rounded half to even to the currency's minor
+// unit, e.g. MulDecimal("0.0825") for a sales tax rate
+func (m MoneyValue) MulDecimal(factor string) (MoneyValue, error) {
+	f, ok := new(big.Rat).SetString(factor)
+	if !ok {
+		return MoneyValue{}, fmt.Errorf("%w: factor %q", ErrInvalidAmount, factor)
+	}
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.minor), f)
+
+	num, denom := product.Num(), product.Denom()
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	// Compare 2*|rem| with denom to round half to even
+	twiceRem := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+	if c := twiceRem.Cmp(denom); c > 0 || (c == 0 && quo.Bit(0) == 1) {
+		if num.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	if !quo.IsInt64() {
+		return MoneyValue{}, ErrAmountOverflow
+	}
+	return MoneyValue{currency: m.currency, minor: quo.Int64()}, nil
+}
+
+// Allocate splits m into len(ratios) parts proportional to ratios without
+// losing a minor unit: remainders go to the first parts, one unit each.
+// Allocate(1, 1, 1) of 10.00 USD is 3.34, 3.33, 3.33.
+func (m MoneyValue) Allocate(ratios ...int64) ([]MoneyValue, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("paypal: allocate needs at least one ratio")
+	}
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, errors.New("paypal: allocate ratios must not be negative")
+		}
+		total += r
+		if total < 0 {
+			return nil, ErrAmountOverflow
+		}
+	}
+	if total == 0 {
+		return nil, errors.New("paypal: allocate ratios must not all be zero")
+	}
+
+	parts := make([]MoneyValue, len(ratios))
+	bigMinor, bigTotal := big.NewInt(m.minor), big.NewInt(total)
+	remainder := m.minor
+	for i, r := range ratios {
+		share := new(big.Int).Mul(bigMinor, big.NewInt(r))
+		share.Quo(share, bigTotal)
+		parts[i] = MoneyValue{currency: m.currency, minor: share.Int64()}
+		remainder -= parts[i].minor
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].minor += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// Split divides m into n parts as equal as possible, see Allocate
+func (m MoneyValue) Split(n int) ([]MoneyValue, error) {
+	if n <= 0 {
+		return nil, errors.New("paypal: split needs a positive number of parts")
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+func (m MoneyValue) sameCurrency(o MoneyValue) error {
+	if m.currency != o.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return nil
+}
+
+// Money converts m into the orders/payments Money struct
+func (m MoneyValue) Money() *Money {
+	return &Money{Currency: m.currency, Value: m.String()}
+}
+
+// PurchaseUnitAmount converts m into a PurchaseUnitAmount without breakdown
+func (m MoneyValue) PurchaseUnitAmount() *PurchaseUnitAmount {
+	return &PurchaseUnitAmount{Currency: m.currency, Value: m.String()}
+}
+
+// AmountPayout converts m into the payouts AmountPayout struct
+func (m MoneyValue) AmountPayout() *AmountPayout {
+	return &AmountPayout{Currency: m.currency, Value: m.String()}
+}
+
+// CurrencyAmount converts m into the Currency struct
+func (m MoneyValue) CurrencyAmount() *Currency {
+	return &Currency{Currency: m.currency, Value: m.String()}
+}
+
+// MarshalJSON encodes m like Money: {"currency_code":"USD","value":"15.00"}
+func (m MoneyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Money{Currency: m.currency, Value: m.String()})
+}
+
+// UnmarshalJSON decodes m from the Money JSON format
+func (m *MoneyValue) UnmarshalJSON(data []byte) error {
+	var money Money
+	if err := json.Unmarshal(data, &money); err != nil {
+		return err
+	}
+	v, err := ParseMoney(money.Currency, money.Value)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// MoneyValue parses the struct into a MoneyValue
+func (m *Money) MoneyValue() (MoneyValue, error) {
+	return ParseMoney(m.Currency, m.Value)
+}
+
+// MoneyValue parses the amount into a MoneyValue, ignoring the breakdown
+func (a *PurchaseUnitAmount) MoneyValue() (MoneyValue, error) {
+	return ParseMoney(a.Currency, a.Value)
+}
+
+// MoneyValue parses the struct into a MoneyValue
+func (a *AmountPayout) MoneyValue() (MoneyValue, error) {
+	return ParseMoney(a.Currency, a.Value)
+}
+
+// MoneyValue parses the struct into a MoneyValue
+func (c *Currency) MoneyValue() (MoneyValue, error) {
+	return ParseMoney(c.Currency, c.Value)
+}
@@ -0,0 +1,181 @@
+package paypal
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		currency string
+		value    string
+		minor    int64
+		str      string
+		wantErr  error
+	}{
+		{"USD", "15.00", 1500, "15.00", nil},
+		{"USD", "15.5", 1550, "15.50", nil},
+		{"USD", "15", 1500, "15.00", nil},
+		{"USD", "0.07", 7, "0.07", nil},
+		{"USD", "-3.20", -320, "-3.20", nil},
+		{"USD", "15.001", 0, "", ErrAmountPrecision},
+		{"EUR", "0.125", 0, "", ErrAmountPrecision},
+		{"JPY", "1500", 1500, "1500", nil},
+		{"JPY", "15.00", 0, "", ErrAmountPrecision},
+		{"JPY", "15.5", 0, "", ErrAmountPrecision},
+		{"HUF", "2990", 2990, "2990", nil},
+		{"HUF", "2990.50", 0, "", ErrAmountPrecision},
+		{"TWD", "300", 300, "300", nil},
+		{"TWD", "300.0", 0, "", ErrAmountPrecision},
+		{"KWD", "1.234", 1234, "1.234", nil},
+		{"KWD", "1.2345", 0, "", ErrAmountPrecision},
+		{"USD", "1,00", 0, "", ErrInvalidAmount},
+		{"USD", "1.", 0, "", ErrInvalidAmount},
+		{"USD", ".50", 0, "", ErrInvalidAmount},
+		{"USD", "1e3", 0, "", ErrInvalidAmount},
+		{"USD", "99999999999999999999", 0, "", ErrAmountOverflow},
+		{"XXX", "1.00", 0, "", ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.currency, tt.value)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseMoney(%s, %q) error = %v, want %v", tt.currency, tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%s, %q): %v", tt.currency, tt.value, err)
+			continue
+		}
+		if m.MinorUnits() != tt.minor || m.String() != tt.str {
+			t.Errorf("ParseMoney(%s, %q) = %d %q, want %d %q", tt.currency, tt.value, m.MinorUnits(), m.String(), tt.minor, tt.str)
+		}
+	}
+}
+
+func TestMoneyValueJSON(t *testing.T) {
+	var m MoneyValue
+	if err := json.Unmarshal([]byte(`{"currency_code":"JPY","value":"1500"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Currency() != "JPY" || m.MinorUnits() != 1500 {
+		t.Fatalf("got %s %d", m.Currency(), m.MinorUnits())
+	}
+	if err := json.Unmarshal([]byte(`{"currency_code":"JPY","value":"1500.00"}`), &m); !errors.Is(err, ErrAmountPrecision) {
+		t.Fatalf("got %v, want ErrAmountPrecision", err)
+	}
+}
+
+func TestMoneyValueAllocate(t *testing.T) {
+	tests := []struct {
+		amount string
+		curr   string
+		ratios []int64
+		want   []int64
+	}{
+		{"10.00", "USD", []int64{1, 1, 1}, []int64{334, 333, 333}},
+		{"0.05", "USD", []int64{3, 7}, []int64{2, 3}},
+		{"100", "JPY", []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"1000", "HUF", []int64{70, 20, 10}, []int64{700, 200, 100}},
+		{"0.02", "USD", []int64{1, 0, 1, 1}, []int64{1, 0, 1, 0}},
+		{"-10.00", "USD", []int64{1, 1, 1}, []int64{-334, -333, -333}},
+		{"0.01", "EUR", []int64{1, 1}, []int64{1, 0}},
+	}
+	for _, tt := range tests {
+		m := MustParseMoney(tt.curr, tt.amount)
+		parts, err := m.Allocate(tt.ratios...)
+		if err != nil {
+			t.Errorf("Allocate(%s %s, %v): %v", tt.amount, tt.curr, tt.ratios, err)
+			continue
+		}
+		var sum int64
+		for i, p := range parts {
+			sum += p.MinorUnits()
+			if p.Currency() != tt.curr {
+				t.Errorf("Allocate(%s %s) part %d has currency %q", tt.amount, tt.curr, i, p.Currency())
+			}
+			if p.MinorUnits() != tt.want[i] {
+				t.Errorf("Allocate(%s %s, %v) part %d = %d, want %d", tt.amount, tt.curr, tt.ratios, i, p.MinorUnits(), tt.want[i])
+			}
+		}
+		if sum != m.MinorUnits() {
+			t.Errorf("Allocate(%s %s, %v) sums to %d, want %d", tt.amount, tt.curr, tt.ratios, sum, m.MinorUnits())
+		}
+	}
+
+	for _, ratios := range [][]int64{nil, {0, 0}, {1, -1}} {
+		if _, err := MustParseMoney("USD", "1.00").Allocate(ratios...); err == nil {
+			t.Errorf("Allocate(%v) accepted", ratios)
+		}
+	}
+}
+
+func TestMoneyValueSplit(t *testing.T) {
+	parts, err := MustParseMoney("TWD", "100").Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parts[0].String() + "," + parts[1].String() + "," + parts[2].String(); got != "34,33,33" {
+		t.Fatalf("Split(3) = %s", got)
+	}
+}
+
+func TestMoneyValueMulDecimal(t *testing.T) {
+	tests := []struct {
+		amount string
+		curr   string
+		factor string
+		want   string
+	}{
+		// Exact halves go to the even neighbour
+		{"0.25", "USD", "0.1", "0.02"},
+		{"0.35", "USD", "0.1", "0.04"},
+		{"0.45", "USD", "0.1", "0.04"},
+		{"-0.25", "USD", "0.1", "-0.02"},
+		{"-0.35", "USD", "0.1", "-0.04"},
+		{"5", "JPY", "0.5", "2"},
+		{"7", "JPY", "0.5", "4"},
+		// Anything past half rounds away from zero, like half-up
+		{"0.251", "KWD", "0.1", "0.025"},
+		{"0.26", "USD", "0.1", "0.03"},
+		{"-0.26", "USD", "0.1", "-0.03"},
+		{"0.24", "USD", "0.1", "0.02"},
+		{"19.99", "USD", "0.0825", "1.65"},
+		{"2990", "HUF", "0.27", "807"},
+		{"10.00", "USD", "3", "30.00"},
+	}
+	for _, tt := range tests {
+		got, err := MustParseMoney(tt.curr, tt.amount).MulDecimal(tt.factor)
+		if err != nil {
+			t.Errorf("%s %s * %s: %v", tt.amount, tt.curr, tt.factor, err)
+			continue
+		}
+		if got.String() != tt.want || got.Currency() != tt.curr {
+			t.Errorf("%s %s * %s = %s %s, want %s", tt.amount, tt.curr, tt.factor, got, got.Currency(), tt.want)
+		}
+	}
+
+	if _, err := MustParseMoney("USD", "1.00").MulDecimal("abc"); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("bad factor: got %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestMoneyValueArithmetic(t *testing.T) {
+	usd := MustParseMoney("USD", "1.10")
+	if _, err := usd.Add(MustParseMoney("EUR", "1.00")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("USD + EUR: got %v, want ErrCurrencyMismatch", err)
+	}
+	sum, err := usd.Add(MustParseMoney("USD", "2.20"))
+	if err != nil || sum.String() != "3.30" {
+		t.Errorf("1.10 + 2.20 = %s, %v", sum, err)
+	}
+	if _, err = MustParseMoney("USD", "92233720368547758.07").Add(MustParseMoney("USD", "0.01")); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("overflow: got %v, want ErrAmountOverflow", err)
+	}
+	total, err := MustParseMoney("JPY", "333").Mul(3)
+	if err != nil || total.String() != "999" {
+		t.Errorf("333 JPY * 3 = %s, %v", total, err)
+	}
+}
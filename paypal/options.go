@@ -0,0 +1,174 @@
+/**
+ * @ClassName options
+ * @Description functional options for NewClient
+ * @Author liwei
+ * @Date 2026/10/18 16:40
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Environment names accepted by WithEnvironment
+const (
+	EnvironmentSandbox string = "sandbox"
+	EnvironmentLive    string = "live"
+)
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(c *Client) error
+
+// NewClient creates a Client for the sandbox unless an option says otherwise.
+// Options are applied in order, so WithTimeout and WithTransport should come
+// after WithHTTPClient.
+func NewClient(clientID, secret string, opts ...ClientOption) (*Client, error) {
+	if clientID == "" || secret == "" {
+		return nil, errors.New("ClientID and Secret are required to create a Client")
+	}
+
+	c := &Client{
+		Client:     &http.Client{},
+		ClientID:   clientID,
+		Secret:     secret,
+		Domain:     APIBaseSandBox,
+		TokenStore: DefaultTokenStore,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// WithEnvironment selects EnvironmentSandbox or EnvironmentLive
+func WithEnvironment(env string) ClientOption {
+	return func(c *Client) error {
+		switch strings.ToLower(env) {
+		case EnvironmentSandbox:
+			c.Domain = APIBaseSandBox
+		case EnvironmentLive:
+			c.Domain = APIBaseLive
+		default:
+			return fmt.Errorf("paypal: unknown environment %q", env)
+		}
+		return nil
+	}
+}
+
+// WithAPIBase sets the API base URL, e.g. APIBaseLive or a local stand-in for tests
+func WithAPIBase(apiBase string) ClientOption {
+	return func(c *Client) error {
+		if apiBase == "" {
+			return errors.New("paypal: empty API base")
+		}
+		c.Domain = strings.TrimRight(apiBase, "/")
+		return nil
+	}
+}
+
+// WithHTTPClient sends requests with httpClient instead of a bare http.Client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("paypal: nil http.Client")
+		}
+		c.Client = httpClient
+		return nil
+	}
+}
+
+// WithTransport sends requests through transport, the http.Client set so far is copied, not changed
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		httpClient := *c.Client
+		httpClient.Transport = transport
+		c.Client = &httpClient
+		return nil
+	}
+}
+
+// WithTimeout limits every attempt of a request including reading the
+// response, the http.Client set so far is copied, not changed
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		httpClient := *c.Client
+		httpClient.Timeout = timeout
+		c.Client = &httpClient
+		return nil
+	}
+}
+
+// WithAcceptLanguage sets the default Accept-Language, e.g. "de_DE", en_US when not set
+func WithAcceptLanguage(language string) ClientOption {
+	return func(c *Client) error {
+		c.acceptLanguage = language
+		return nil
+	}
+}
+
+// WithReturnRepresentation sends Prefer: return=representation, so PayPal
+// answers updates with the full resource instead of 204 or a minimal body
+func WithReturnRepresentation() ClientOption {
+	return func(c *Client) error {
+		c.returnRepresentation = true
+		return nil
+	}
+}
+
+// WithPartnerAttributionID sends the PayPal-Partner-Attribution-Id (BN code) of a partner integration
+func WithPartnerAttributionID(id string) ClientOption {
+	return func(c *Client) error {
+		c.partnerAttributionID = id
+		return nil
+	}
+}
+
+// WithLogger logs every request attempt to logger, redacted by redactor or NewRedactor() when nil
+func WithLogger(logger Logger, redactor *Redactor) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		c.Redactor = redactor
+		return nil
+	}
+}
+
+// WithRetryPolicy retries transient failures according to policy, e.g. DefaultRetryPolicy
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.Retry = policy
+		return nil
+	}
+}
+
+// WithTokenStore shares access tokens through store instead of DefaultTokenStore
+func WithTokenStore(store TokenStore) ClientOption {
+	return func(c *Client) error {
+		c.TokenStore = store
+		return nil
+	}
+}
+
+// WithCertFetcher loads webhook certificates with fetcher instead of DefaultCertFetcher
+func WithCertFetcher(fetcher CertFetcher) ClientOption {
+	return func(c *Client) error {
+		c.CertFetcher = fetcher
+		return nil
+	}
+}
+
+// WithMiddleware appends middlewares to the chain, see Client.Use
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, middlewares...)
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	transport := &http.Transport{}
+	c, err := NewClient("clientID", "secret",
+		WithEnvironment("LIVE"),
+		WithHTTPClient(httpClient),
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithRetryPolicy(DefaultRetryPolicy),
+		WithTokenStore(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Domain != APIBaseLive {
+		t.Errorf("Domain %s, want %s", c.Domain, APIBaseLive)
+	}
+	if c.Client.Transport != transport || c.Client.Timeout != 5*time.Second {
+		t.Errorf("http.Client %+v", c.Client)
+	}
+	if httpClient.Transport != nil || httpClient.Timeout != time.Minute {
+		t.Errorf("the caller's http.Client was changed: %+v", httpClient)
+	}
+	if c.Retry != DefaultRetryPolicy || c.TokenStore != nil {
+		t.Errorf("Retry %v, TokenStore %v", c.Retry, c.TokenStore)
+	}
+
+	c, err = NewClient("clientID", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Domain != APIBaseSandBox || c.TokenStore != DefaultTokenStore {
+		t.Errorf("defaults: Domain %s, TokenStore %v", c.Domain, c.TokenStore)
+	}
+	if c, err = NewClient("clientID", "secret", WithEnvironment("sandbox")); err != nil || c.Domain != APIBaseSandBox {
+		t.Errorf("sandbox: %v", err)
+	}
+	if c, err = NewClient("clientID", "secret", WithAPIBase("http://localhost:8080/")); err != nil || c.Domain != "http://localhost:8080" {
+		t.Errorf("WithAPIBase: %v", err)
+	}
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		secret string
+		opts   []ClientOption
+	}{
+		{"no client id", "", "secret", nil},
+		{"no secret", "clientID", "", nil},
+		{"unknown environment", "clientID", "secret", []ClientOption{WithEnvironment("staging")}},
+		{"empty api base", "clientID", "secret", []ClientOption{WithAPIBase("")}},
+		{"nil http client", "clientID", "secret", []ClientOption{WithHTTPClient(nil)}},
+	}
+	for _, tt := range tests {
+		if _, err := NewClient(tt.id, tt.secret, tt.opts...); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
+
+func TestClientOptionHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []ClientOption
+		header map[string]string
+	}{
+		{
+			name:   "defaults",
+			header: map[string]string{"Prefer": "", "PayPal-Partner-Attribution-Id": "", "Accept-Language": "en_US"},
+		},
+		{
+			name:   "return representation",
+			opts:   []ClientOption{WithReturnRepresentation()},
+			header: map[string]string{"Prefer": "return=representation"},
+		},
+		{
+			name:   "partner attribution",
+			opts:   []ClientOption{WithPartnerAttributionID("EXAMPLE_SP_PPCP")},
+			header: map[string]string{"PayPal-Partner-Attribution-Id": "EXAMPLE_SP_PPCP"},
+		},
+		{
+			name:   "accept language",
+			opts:   []ClientOption{WithAcceptLanguage("de_DE")},
+			header: map[string]string{"Accept-Language": "de_DE"},
+		},
+		{
+			name:   "middleware",
+			opts:   []ClientOption{WithMiddleware(HeaderMiddleware("X-Trace-Id", func(req *http.Request) string { return "trace-1" }))},
+			header: map[string]string{"X-Trace-Id": "trace-1"},
+		},
+	}
+	for _, tt := range tests {
+		rec := newAPIRecorder(t, `{"id":"5O190127TN364715T","status":"CREATED"}`)
+		c, err := NewClient("clientID", "secret", append([]ClientOption{WithAPIBase(rec.URL), WithTokenStore(nil)}, tt.opts...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = c.GetOrder(context.Background(), "5O190127TN364715T"); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		req := rec.last(t)
+		for k, v := range tt.header {
+			if got := req.Header.Get(k); got != v {
+				t.Errorf("%s: %s %q, want %q", tt.name, k, got, v)
+			}
+		}
+	}
+}
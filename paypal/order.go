@@ -9,59 +9,18 @@
 package paypal
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
-	"net/http"
-	"time"
 )
 
+// CreateOrder - Use this call to create an order. The purchase unit amounts
+// are validated first, see PurchaseUnitAmount.Validate
+// Endpoint: POST /v2/checkout/orders
 func (c *Client) CreateOrder(ctx context.Context, createOrder CreateOrder) (*Order, error) {
-	var createOrderReponse Order
-	postUrl:= c.Domain+"/v2/checkout/orders"
-	var buf io.Reader
-	jsonStr, err := json.Marshal(&createOrder)
-	if err!=nil{
-		return nil,err
-	}
-	buf = bytes.NewBuffer(jsonStr)
-	tr := &http.Transport{
-		MaxIdleConns:       30,
-		IdleConnTimeout:    100 * time.Second,
-		DisableCompression: true,
-		TLSClientConfig:&tls.Config{InsecureSkipVerify:true},
-	}
-	client := &http.Client{
-		Transport:tr,
-	}
-	req,err:=http.NewRequest("POST",postUrl,ioutil.NopCloser(buf))
-	if err!=nil{
-		return nil,err
-	}
-	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
-	req.Header.Add("Authorization","Bearer "+c.Token.Token)
-
-	resp, err := client.Do(req)
-	if err!=nil{
-		return nil,err
-	}
-	defer resp.Body.Close()
-	//
-	//fmt.Println("response Status:", resp.Status)
-	//fmt.Println("response Headers:", resp.Header)
-	str,err:=ioutil.ReadAll(resp.Body)
-	if err!=nil{
-		return nil,err
-	}
-	err1:=json.Unmarshal(str,&createOrderReponse)
-	if err1!=nil{
-		return nil,err
-	}
-	return &createOrderReponse,nil
+	if err := createOrder.Validate(); err != nil {
+		return &Order{}, err
+	}
+	return c.createOrder(ctx, createOrder, "")
 }
 
 
@@ -81,9 +40,50 @@ func (c *Client) CreateOrderWithPaypalRequestID(ctx context.Context,
 		ApplicationContext *ApplicationContext   `json:"application_context,omitempty"`
 	}
 
+	if err := validatePurchaseUnitRequests(purchaseUnits); err != nil {
+		return &Order{}, err
+	}
+
+	return c.createOrder(ctx, createOrderRequest{Intent: intent, PurchaseUnits: purchaseUnits, Payer: payer, ApplicationContext: appContext}, requestID)
+}
+
+// CreateOrderWithPaymentSource creates an order paid with paymentSource, e.g. a
+// saved card or wallet from VaultedPaymentSource. The buyer doesn't approve
+// such an order, with intent CAPTURE it is captured right away. A requestID is
+// generated when empty, PayPal requires one for orders with a payment source.
+// Endpoint: POST /v2/checkout/orders
+func (c *Client) CreateOrderWithPaymentSource(ctx context.Context,
+	intent string,
+	purchaseUnits []PurchaseUnitRequest,
+	paymentSource *PaymentSource,
+	requestID string,
+) (*Order, error) {
+	type createOrderRequest struct {
+		Intent        string                `json:"intent"`
+		PurchaseUnits []PurchaseUnitRequest `json:"purchase_units"`
+		PaymentSource *PaymentSource        `json:"payment_source"`
+	}
+
+	if err := validatePurchaseUnitRequests(purchaseUnits); err != nil {
+		return &Order{}, err
+	}
+	if requestID == "" {
+		var err error
+		if requestID, err = NewRequestID(); err != nil {
+			return &Order{}, err
+		}
+	}
+
+	return c.createOrder(ctx, createOrderRequest{Intent: intent, PurchaseUnits: purchaseUnits, PaymentSource: paymentSource}, requestID)
+}
+
+// createOrder is the single order creation path shared by CreateOrder,
+// CreateOrderWithPaypalRequestID and CreateOrderWithPaymentSource, payload is
+// marshaled as the request body. Callers validate the purchase units first.
+func (c *Client) createOrder(ctx context.Context, payload interface{}, requestID string) (*Order, error) {
 	order := &Order{}
 
-	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v2/checkout/orders"), createOrderRequest{Intent: intent, PurchaseUnits: purchaseUnits, Payer: payer, ApplicationContext: appContext})
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v2/checkout/orders"), payload)
 	if err != nil {
 		return order, err
 	}
@@ -97,4 +97,140 @@ func (c *Client) CreateOrderWithPaypalRequestID(ctx context.Context,
 	}
 
 	return order, nil
-}
\ No newline at end of file
+}
+
+
+// GetOrder retrieves order by ID
+// Endpoint: GET /v2/checkout/orders/ID
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/checkout/orders/", orderID), nil)
+	if err != nil {
+		return order, err
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// UpdateOrder updates an order in CREATED or APPROVED status with JSON Patch operations
+// Endpoint: PATCH /v2/checkout/orders/ID
+func (c *Client) UpdateOrder(ctx context.Context, orderID string, operations []PatchOperation) error {
+	return c.UpdateOrderWithPaypalRequestID(ctx, orderID, operations, "")
+}
+
+// UpdateOrderWithPaypalRequestID - Use this call to update an order with idempotency
+// Endpoint: PATCH /v2/checkout/orders/ID
+func (c *Client) UpdateOrderWithPaypalRequestID(ctx context.Context, orderID string, operations []PatchOperation, requestID string) error {
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.Domain, "/v2/checkout/orders/", orderID), operations)
+	if err != nil {
+		return err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// AuthorizeOrder authorizes payment for an order created with intent AUTHORIZE
+// Endpoint: POST /v2/checkout/orders/ID/authorize
+func (c *Client) AuthorizeOrder(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest) (*Order, error) {
+	return c.AuthorizeOrderWithPaypalRequestID(ctx, orderID, authorizeOrderRequest, "")
+}
+
+// AuthorizeOrderWithPaypalRequestID - Use this call to authorize an order with idempotency
+// Endpoint: POST /v2/checkout/orders/ID/authorize
+func (c *Client) AuthorizeOrderWithPaypalRequestID(ctx context.Context, orderID string, authorizeOrderRequest AuthorizeOrderRequest, requestID string) (*Order, error) {
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/checkout/orders/", orderID, "/authorize"), authorizeOrderRequest)
+	if err != nil {
+		return order, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// CaptureOrder captures payment for an order created with intent CAPTURE
+// Endpoint: POST /v2/checkout/orders/ID/capture
+func (c *Client) CaptureOrder(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest) (*Order, error) {
+	return c.CaptureOrderWithPaypalRequestID(ctx, orderID, captureOrderRequest, "")
+}
+
+// CaptureOrderWithPaypalRequestID - Use this call to capture an order with idempotency,
+// retrying with the same requestID never captures twice
+// Endpoint: POST /v2/checkout/orders/ID/capture
+func (c *Client) CaptureOrderWithPaypalRequestID(ctx context.Context, orderID string, captureOrderRequest CaptureOrderRequest, requestID string) (*Order, error) {
+	order := &Order{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/checkout/orders/", orderID, "/capture"), captureOrderRequest)
+	if err != nil {
+		return order, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, order); err != nil {
+		return order, err
+	}
+
+	return order, nil
+}
+
+// PurchaseUnitPatchPath builds the JSON Patch path of a purchase unit field,
+// e.g. PurchaseUnitPatchPath("default", "amount") for use in PatchOperation.Path
+func PurchaseUnitPatchPath(referenceID, field string) string {
+	path := fmt.Sprintf("/purchase_units/@reference_id=='%s'", referenceID)
+	if field != "" {
+		path += "/" + field
+	}
+	return path
+}
+
+// Captures returns the captures of all purchase units of the order
+func (o *Order) Captures() []CaptureAmount {
+	var captures []CaptureAmount
+	for _, unit := range o.PurchaseUnits {
+		if unit.Payments != nil {
+			captures = append(captures, unit.Payments.Captures...)
+		}
+	}
+	return captures
+}
+
+// Authorizations returns the authorizations of all purchase units of the order
+func (o *Order) Authorizations() []Authorization {
+	var authorizations []Authorization
+	for _, unit := range o.PurchaseUnits {
+		if unit.Payments != nil {
+			authorizations = append(authorizations, unit.Payments.Authorizations...)
+		}
+	}
+	return authorizations
+}
+
+// Link returns the HATEOAS link with the given rel, e.g. "approve"
+func (o *Order) Link(rel string) *Link {
+	for i := range o.Links {
+		if o.Links[i].Rel == rel {
+			return &o.Links[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateOrderBody(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			w.Write([]byte(`{"access_token":"A21AAF","token_type":"Bearer","expires_in":32400}`))
+			return
+		}
+		if r.Method != "POST" || r.URL.Path != "/v2/checkout/orders" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"5O190127TN364715T","status":"CREATED"}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TokenStore = nil
+
+	order, err := c.CreateOrder(context.Background(), CreateOrder{
+		Intent: OrderIntentCapture,
+		PurchaseUnits: []PurchaseUnit{
+			{ReferenceID: "default", Amount: &PurchaseUnitAmount{Currency: "USD", Value: "15.00"}},
+		},
+		ApplicationContext: ApplicationContext{BrandName: "Example Shop", ReturnURL: "https://example.com/return"},
+		Resource:           &CallBackPayoutItem{Receiver: "payee@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "5O190127TN364715T" {
+		t.Errorf("order %+v", order)
+	}
+
+	var sent map[string]json.RawMessage
+	if err = json.Unmarshal(body, &sent); err != nil {
+		t.Fatalf("%s: %v", body, err)
+	}
+	for _, key := range []string{"intent", "purchase_units", "application_context"} {
+		if _, ok := sent[key]; !ok {
+			t.Errorf("%s missing from %s", key, body)
+		}
+	}
+	if len(sent) != 3 {
+		t.Errorf("unexpected fields in %s", body)
+	}
+	if string(sent["intent"]) != `"CAPTURE"` {
+		t.Errorf("intent %s", sent["intent"])
+	}
+}
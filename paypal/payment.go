@@ -0,0 +1,207 @@
+/**
+ * @ClassName payment
+ * @Description payments v2 captures and refunds
+ * @Author liwei
+ * @Date 2026/10/18 13:05
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetCapture retrieves a captured payment by ID
+// Endpoint: GET /v2/payments/captures/ID
+func (c *Client) GetCapture(ctx context.Context, captureID string) (*CaptureDetailsResponse, error) {
+	capture := &CaptureDetailsResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/payments/captures/", captureID), nil)
+	if err != nil {
+		return capture, err
+	}
+
+	if err = c.SendWithAuth(req, capture); err != nil {
+		return capture, err
+	}
+
+	return capture, nil
+}
+
+// RefundCapture refunds a captured payment, fully when refundCaptureRequest.Amount is nil
+// Endpoint: POST /v2/payments/captures/ID/refund
+func (c *Client) RefundCapture(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest) (*Refund, error) {
+	return c.RefundCaptureWithPaypalRequestID(ctx, captureID, refundCaptureRequest, "")
+}
+
+// RefundCaptureWithPaypalRequestID - Use this call to refund a capture with idempotency,
+// retrying with the same requestID never refunds twice
+// Endpoint: POST /v2/payments/captures/ID/refund
+func (c *Client) RefundCaptureWithPaypalRequestID(ctx context.Context, captureID string, refundCaptureRequest RefundCaptureRequest, requestID string) (*Refund, error) {
+	refund := &Refund{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/payments/captures/", captureID, "/refund"), refundCaptureRequest)
+	if err != nil {
+		return refund, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, refund); err != nil {
+		return refund, err
+	}
+
+	return refund, nil
+}
+
+// GetRefund retrieves a refund by ID
+// Endpoint: GET /v2/payments/refunds/ID
+func (c *Client) GetRefund(ctx context.Context, refundID string) (*Refund, error) {
+	refund := &Refund{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/payments/refunds/", refundID), nil)
+	if err != nil {
+		return refund, err
+	}
+
+	if err = c.SendWithAuth(req, refund); err != nil {
+		return refund, err
+	}
+
+	return refund, nil
+}
+
+// GetAuthorization retrieves an authorized payment by ID
+// Endpoint: GET /v2/payments/authorizations/ID
+func (c *Client) GetAuthorization(ctx context.Context, authID string) (*Authorization, error) {
+	authorization := &Authorization{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v2/payments/authorizations/", authID), nil)
+	if err != nil {
+		return authorization, err
+	}
+
+	if err = c.SendWithAuth(req, authorization); err != nil {
+		return authorization, err
+	}
+
+	return authorization, nil
+}
+
+// CaptureAuthorization captures an authorized payment, the full authorized amount when
+// paymentCaptureRequest.Amount is nil. Set FinalCapture to release the remaining amount.
+// Endpoint: POST /v2/payments/authorizations/ID/capture
+func (c *Client) CaptureAuthorization(ctx context.Context, authID string, paymentCaptureRequest PaymentCaptureRequest) (*CaptureDetailsResponse, error) {
+	return c.CaptureAuthorizationWithPaypalRequestID(ctx, authID, paymentCaptureRequest, "")
+}
+
+// CaptureAuthorizationWithPaypalRequestID - Use this call to capture an authorization with idempotency
+// Endpoint: POST /v2/payments/authorizations/ID/capture
+func (c *Client) CaptureAuthorizationWithPaypalRequestID(ctx context.Context, authID string, paymentCaptureRequest PaymentCaptureRequest, requestID string) (*CaptureDetailsResponse, error) {
+	capture := &CaptureDetailsResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/payments/authorizations/", authID, "/capture"), paymentCaptureRequest)
+	if err != nil {
+		return capture, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, capture); err != nil {
+		return capture, err
+	}
+
+	return capture, nil
+}
+
+// ReauthorizeAuthorization reauthorizes an authorized payment after its three day
+// honor period, for the original amount when amount is nil
+// Endpoint: POST /v2/payments/authorizations/ID/reauthorize
+func (c *Client) ReauthorizeAuthorization(ctx context.Context, authID string, amount *Money) (*Authorization, error) {
+	return c.ReauthorizeAuthorizationWithPaypalRequestID(ctx, authID, amount, "")
+}
+
+// ReauthorizeAuthorizationWithPaypalRequestID - Use this call to reauthorize with idempotency
+// Endpoint: POST /v2/payments/authorizations/ID/reauthorize
+func (c *Client) ReauthorizeAuthorizationWithPaypalRequestID(ctx context.Context, authID string, amount *Money, requestID string) (*Authorization, error) {
+	type reauthorizeRequest struct {
+		Amount *Money `json:"amount,omitempty"`
+	}
+
+	authorization := &Authorization{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/payments/authorizations/", authID, "/reauthorize"), reauthorizeRequest{Amount: amount})
+	if err != nil {
+		return authorization, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, authorization); err != nil {
+		return authorization, err
+	}
+
+	return authorization, nil
+}
+
+// VoidAuthorization voids an authorized payment that is not fully captured. PayPal
+// answers 204 without a body unless the client asks for return=representation,
+// in which case the voided authorization is decoded, otherwise only its ID is set.
+// Endpoint: POST /v2/payments/authorizations/ID/void
+func (c *Client) VoidAuthorization(ctx context.Context, authID string) (*Authorization, error) {
+	return c.VoidAuthorizationWithPaypalRequestID(ctx, authID, "")
+}
+
+// VoidAuthorizationWithPaypalRequestID - Use this call to void an authorization with idempotency
+// Endpoint: POST /v2/payments/authorizations/ID/void
+func (c *Client) VoidAuthorizationWithPaypalRequestID(ctx context.Context, authID string, requestID string) (*Authorization, error) {
+	authorization := &Authorization{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v2/payments/authorizations/", authID, "/void"), nil)
+	if err != nil {
+		return authorization, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, authorization); err != nil {
+		return authorization, err
+	}
+
+	if authorization.ID == "" {
+		authorization.ID = authID
+		authorization.Status = AuthorizationStatusVoided
+	}
+
+	return authorization, nil
+}
+
+// IsCapturable reports whether the authorization can still be captured
+func (a *Authorization) IsCapturable() bool {
+	return a.Status == AuthorizationStatusCreated || a.Status == AuthorizationStatusPartiallyCaptured
+}
+
+// IsVoidable reports whether the authorization can still be voided, PayPal
+// refuses to void a PENDING one
+func (a *Authorization) IsVoidable() bool {
+	return a.Status == AuthorizationStatusCreated || a.Status == AuthorizationStatusPartiallyCaptured
+}
+
+// IsFinal reports whether the authorization reached a status it can't leave
+func (a *Authorization) IsFinal() bool {
+	switch a.Status {
+	case AuthorizationStatusCaptured, AuthorizationStatusDenied, AuthorizationStatusExpired, AuthorizationStatusVoided:
+		return true
+	}
+	return false
+}
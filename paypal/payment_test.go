@@ -0,0 +1,140 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefundCapture(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id":"1JU08902781691411","status":"COMPLETED","amount":{"currency_code":"USD","value":"10.99"},"seller_payable_breakdown":{"gross_amount":{"currency_code":"USD","value":"10.99"},"paypal_fee":{"currency_code":"USD","value":"0"},"net_amount":{"currency_code":"USD","value":"10.99"}}}`)
+	c := rec.client(t, nil)
+
+	refund, err := c.RefundCaptureWithPaypalRequestID(context.Background(), "2GG279541U471931P", RefundCaptureRequest{
+		Amount:      &Money{Currency: "USD", Value: "10.99"},
+		InvoiceID:   "INVOICE-123",
+		NoteToPayer: "Defective product",
+	}, "123e4567-e89b-12d3-a456-426655440020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "POST" || req.Path != "/v2/payments/captures/2GG279541U471931P/refund" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Header.Get("PayPal-Request-Id"); got != "123e4567-e89b-12d3-a456-426655440020" {
+		t.Errorf("PayPal-Request-Id %q", got)
+	}
+	if want := `{"amount":{"currency_code":"USD","value":"10.99"},"invoice_id":"INVOICE-123","note_to_payer":"Defective product"}`; !jsonEqual(t, req.Body, want) {
+		t.Errorf("body %s, want %s", req.Body, want)
+	}
+	if refund.ID != "1JU08902781691411" || refund.Amount.Value != "10.99" || refund.SellerPayableBreakdown == nil {
+		t.Errorf("refund %+v", refund)
+	}
+
+	if _, err = c.RefundCapture(context.Background(), "2GG279541U471931P", RefundCaptureRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	req = rec.last(t)
+	if got := req.Header.Get("PayPal-Request-Id"); got != "" {
+		t.Errorf("full refund sent PayPal-Request-Id %q", got)
+	}
+	if !jsonEqual(t, req.Body, `{}`) {
+		t.Errorf("full refund body %s", req.Body)
+	}
+}
+
+func TestGetCaptureAndRefund(t *testing.T) {
+	rec := newAPIRecorder(t,
+		`{"id":"2GG279541U471931P","status":"COMPLETED","amount":{"currency_code":"USD","value":"100.00"},"final_capture":true}`,
+		`{"id":"1JU08902781691411","status":"PENDING","status_details":{"reason":"ECHECK"}}`,
+	)
+	c := rec.client(t, nil)
+
+	capture, err := c.GetCapture(context.Background(), "2GG279541U471931P")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req := rec.last(t); req.Method != "GET" || req.Path != "/v2/payments/captures/2GG279541U471931P" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if capture.ID != "2GG279541U471931P" || capture.Status != "COMPLETED" {
+		t.Errorf("capture %+v", capture)
+	}
+
+	refund, err := c.GetRefund(context.Background(), "1JU08902781691411")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req := rec.last(t); req.Method != "GET" || req.Path != "/v2/payments/refunds/1JU08902781691411" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if refund.Status != "PENDING" || refund.StatusDetails == nil || refund.StatusDetails.Reason != "ECHECK" {
+		t.Errorf("refund %+v", refund)
+	}
+}
+
+func TestVoidAuthorization(t *testing.T) {
+	var attempts int32
+	var requestIDs []string
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v2/payments/authorizations/0VF52814937998046/void" {
+			http.NotFound(w, r)
+			return
+		}
+		requestIDs = append(requestIDs, r.Header.Get("PayPal-Request-Id"))
+		if atomic.AddInt32(&attempts, 1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := srv.client(t, nil)
+	c.Retry = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	authorization, err := c.VoidAuthorizationWithPaypalRequestID(context.Background(), "0VF52814937998046", "void-0VF52814937998046")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authorization.ID != "0VF52814937998046" || authorization.Status != AuthorizationStatusVoided {
+		t.Errorf("authorization %+v", authorization)
+	}
+	if len(requestIDs) != 2 || requestIDs[0] != "void-0VF52814937998046" || requestIDs[1] != requestIDs[0] {
+		t.Fatalf("attempts with request ids %q, want the same id twice", requestIDs)
+	}
+
+	// Without a request id the failed POST must not be retried
+	requestIDs = nil
+	if _, err = c.VoidAuthorization(context.Background(), "0VF52814937998046"); !IsStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("got %v, want 503", err)
+	}
+	if len(requestIDs) != 1 || requestIDs[0] != "" {
+		t.Fatalf("attempts with request ids %q", requestIDs)
+	}
+}
+
+func TestAuthorizationStatus(t *testing.T) {
+	tests := []struct {
+		status     string
+		capturable bool
+		voidable   bool
+		final      bool
+	}{
+		{AuthorizationStatusCreated, true, true, false},
+		{AuthorizationStatusPartiallyCaptured, true, true, false},
+		{AuthorizationStatusPending, false, false, false},
+		{AuthorizationStatusCaptured, false, false, true},
+		{AuthorizationStatusDenied, false, false, true},
+		{AuthorizationStatusExpired, false, false, true},
+		{AuthorizationStatusVoided, false, false, true},
+		{"", false, false, false},
+	}
+	for _, tt := range tests {
+		a := &Authorization{Status: tt.status}
+		if a.IsCapturable() != tt.capturable || a.IsVoidable() != tt.voidable || a.IsFinal() != tt.final {
+			t.Errorf("%q: capturable %t, voidable %t, final %t", tt.status, a.IsCapturable(), a.IsVoidable(), a.IsFinal())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/**
+ * @ClassName payout
+ * @Description payouts v1 batches and items
+ * @Author liwei
+ * @Date 2026/10/18 19:30
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxPayoutBatchPageSize is the largest page of items GetPayoutBatch returns
+const MaxPayoutBatchPageSize = 1000
+
+// ErrSenderBatchIDRequired is returned by CreatePayout without a sender batch id.
+// PayPal refuses a sender_batch_id used in the last 30 days, which makes it the
+// key that keeps a retried payout from paying twice.
+var ErrSenderBatchIDRequired = errors.New("paypal: payout needs SenderBatchHeader.SenderBatchID")
+
+// CreatePayout sends a batch of payouts, its SenderBatchID must be set
+// Endpoint: POST /v1/payments/payouts
+func (c *Client) CreatePayout(ctx context.Context, payout Payout) (*PayoutResponse, error) {
+	return c.CreatePayoutWithPaypalRequestID(ctx, payout, "")
+}
+
+// CreatePayoutWithPaypalRequestID - Use this call to send a payout batch with idempotency,
+// a retry with the same requestID returns the batch created first
+// Endpoint: POST /v1/payments/payouts
+func (c *Client) CreatePayoutWithPaypalRequestID(ctx context.Context, payout Payout, requestID string) (*PayoutResponse, error) {
+	response := &PayoutResponse{}
+
+	if payout.SenderBatchHeader == nil || payout.SenderBatchHeader.SenderBatchID == "" {
+		return response, ErrSenderBatchIDRequired
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/payments/payouts"), payout)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetPayoutBatch returns a payout batch with one page of its items, params may be nil
+// Endpoint: GET /v1/payments/payouts/ID
+func (c *Client) GetPayoutBatch(ctx context.Context, payoutBatchID string, params *PayoutBatchParams) (*PayoutResponse, error) {
+	response := &PayoutResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		if params.Page > 0 {
+			q.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(params.PageSize))
+		}
+		if params.TotalRequired {
+			q.Set("total_required", "true")
+		}
+	}
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s%s", c.Domain, "/v1/payments/payouts/", payoutBatchID), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetPayoutBatchItems returns every item of a payout batch, walking all pages.
+// When the response has no total_pages it keeps paging while there is a
+// "next" link or the last page was full.
+// Endpoint: GET /v1/payments/payouts/ID
+func (c *Client) GetPayoutBatchItems(ctx context.Context, payoutBatchID string) ([]PayoutItemResponse, error) {
+	var items []PayoutItemResponse
+	for page := 1; ; page++ {
+		response, err := c.GetPayoutBatch(ctx, payoutBatchID, &PayoutBatchParams{
+			Page:          page,
+			PageSize:      MaxPayoutBatchPageSize,
+			TotalRequired: true,
+		})
+		if err != nil {
+			return items, err
+		}
+		items = append(items, response.Items...)
+		if len(response.Items) == 0 {
+			return items, nil
+		}
+		if response.TotalPages > 0 {
+			if page >= response.TotalPages {
+				return items, nil
+			}
+			continue
+		}
+		if response.Link("next") == nil && len(response.Items) < MaxPayoutBatchPageSize {
+			return items, nil
+		}
+	}
+}
+
+// Link returns the HATEOAS link with the given rel, e.g. "next"
+func (r *PayoutResponse) Link(rel string) *Link {
+	for i := range r.Links {
+		if r.Links[i].Rel == rel {
+			return &r.Links[i]
+		}
+	}
+	return nil
+}
+
+// GetPayoutItem returns a single payout item
+// Endpoint: GET /v1/payments/payouts-item/ID
+func (c *Client) GetPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error) {
+	item := &PayoutItemResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/payments/payouts-item/", payoutItemID), nil)
+	if err != nil {
+		return item, err
+	}
+
+	if err = c.SendWithAuth(req, item); err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+// CancelUnclaimedPayoutItem cancels a payout item nobody claimed yet and
+// returns its amount to the sender, see IsCancelable
+// Endpoint: POST /v1/payments/payouts-item/ID/cancel
+func (c *Client) CancelUnclaimedPayoutItem(ctx context.Context, payoutItemID string) (*PayoutItemResponse, error) {
+	item := &PayoutItemResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/payments/payouts-item/", payoutItemID, "/cancel"), nil)
+	if err != nil {
+		return item, err
+	}
+
+	if err = c.SendWithAuth(req, item); err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+// IsFinal reports whether the batch finished processing
+func (h *BatchHeader) IsFinal() bool {
+	switch h.BatchStatus {
+	case PayoutBatchStatusDenied, PayoutBatchStatusSuccess, PayoutBatchStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// IsCancelable reports whether CancelUnclaimedPayoutItem can cancel the item
+func (i *PayoutItemResponse) IsCancelable() bool {
+	return i.TransactionStatus == PayoutItemStatusUnclaimed
+}
+
+// IsFinal reports whether PayPal finished processing the item. A successful
+// item can still be refunded or reversed later.
+func (i *PayoutItemResponse) IsFinal() bool {
+	switch i.TransactionStatus {
+	case PayoutItemStatusSuccess, PayoutItemStatusFailed, PayoutItemStatusReturned,
+		PayoutItemStatusRefunded, PayoutItemStatusReversed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// payoutPages serves GET /v1/payments/payouts/BATCH-1 with pages[page-1] items
+// per page. totalPages and nextLinks control what each page tells about the rest.
+func payoutPages(t *testing.T, pages []int, totalPages int, nextLinks bool) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			w.Write([]byte(`{"access_token":"A21AAF","token_type":"Bearer","expires_in":32400}`))
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if r.URL.Path != "/v1/payments/payouts/BATCH-1" || page < 1 {
+			http.Error(w, `{"name":"INVALID_REQUEST"}`, http.StatusBadRequest)
+			return
+		}
+
+		response := PayoutResponse{TotalPages: totalPages, BatchHeader: &BatchHeader{}}
+		if page <= len(pages) {
+			for i := 0; i < pages[page-1]; i++ {
+				response.Items = append(response.Items, PayoutItemResponse{PayoutItemID: fmt.Sprintf("ITEM-%d-%d", page, i)})
+			}
+			if nextLinks && page < len(pages) {
+				response.Links = append(response.Links, Link{Rel: "next", Href: fmt.Sprintf("%s%s?page=%d", "http://"+r.Host, r.URL.Path, page+1)})
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestGetPayoutBatchItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		pages      []int
+		totalPages int
+		nextLinks  bool
+		want       int
+		requests   int32
+	}{
+		{"total_pages", []int{MaxPayoutBatchPageSize, 2}, 2, false, MaxPayoutBatchPageSize + 2, 2},
+		{"next links without total_pages", []int{2, 2, 1}, 0, true, 5, 3},
+		{"full pages without total_pages", []int{MaxPayoutBatchPageSize, 3}, 0, false, MaxPayoutBatchPageSize + 3, 2},
+		{"full last page without total_pages", []int{MaxPayoutBatchPageSize}, 0, false, MaxPayoutBatchPageSize, 2},
+		{"single short page", []int{4}, 0, false, 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := payoutPages(t, tt.pages, tt.totalPages, tt.nextLinks)
+			c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.TokenStore = nil
+
+			items, err := c.GetPayoutBatchItems(context.Background(), "BATCH-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != tt.want {
+				t.Fatalf("got %d items, want %d", len(items), tt.want)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.requests {
+				t.Fatalf("%d page requests, want %d", got, tt.requests)
+			}
+			seen := make(map[string]bool)
+			for _, item := range items {
+				if seen[item.PayoutItemID] {
+					t.Fatalf("item %s returned twice", item.PayoutItemID)
+				}
+				seen[item.PayoutItemID] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/**
+ * @ClassName product
+ * @Description catalog products v1
+ * @Author liwei
+ * @Date 2026/10/18 20:10
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CreateProduct adds a product to the catalog
+// Endpoint: POST /v1/catalogs/products
+func (c *Client) CreateProduct(ctx context.Context, product Product) (*CreateProductResponse, error) {
+	return c.CreateProductWithPaypalRequestID(ctx, product, "")
+}
+
+// CreateProductWithPaypalRequestID - Use this call to create a product with idempotency
+// Endpoint: POST /v1/catalogs/products
+func (c *Client) CreateProductWithPaypalRequestID(ctx context.Context, product Product, requestID string) (*CreateProductResponse, error) {
+	response := &CreateProductResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/catalogs/products"), product)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ListProducts returns one page of the catalog, params may be nil
+// Endpoint: GET /v1/catalogs/products
+func (c *Client) ListProducts(ctx context.Context, params *ProductListParameters) (*ListProductsResponse, error) {
+	response := &ListProductsResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		params.ListParams.setQuery(q)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/catalogs/products"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetProduct returns a catalog product
+// Endpoint: GET /v1/catalogs/products/ID
+func (c *Client) GetProduct(ctx context.Context, productID string) (*CreateProductResponse, error) {
+	response := &CreateProductResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/catalogs/products/", productID), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// UpdateProduct patches the description, category, image_url or home_url of a
+// product. The catalog API has no delete, products stay listed for their plans.
+// Endpoint: PATCH /v1/catalogs/products/ID
+func (c *Client) UpdateProduct(ctx context.Context, productID string, operations []PatchOperation) error {
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.Domain, "/v1/catalogs/products/", productID), operations)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
@@ -0,0 +1,363 @@
+/**
+ * @ClassName reporting
+ * @Description transaction search and balances of the reporting API
+ * @Author liwei
+ * @Date 2026/10/18 17:50
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxTransactionSearchRange is the longest date range one transaction search may cover
+	MaxTransactionSearchRange = time.Duration(31*24) * time.Hour
+	// MaxTransactionSearchPageSize is the largest page size of a transaction search
+	MaxTransactionSearchPageSize = 500
+
+	// reportingTimeLayout is the date format of reporting query parameters
+	reportingTimeLayout = "2006-01-02T15:04:05-0700"
+)
+
+// Values of TransactionSearchRequest.Fields
+const (
+	TransactionFieldTransactionInfo string = "transaction_info"
+	TransactionFieldPayerInfo       string = "payer_info"
+	TransactionFieldShippingInfo    string = "shipping_info"
+	TransactionFieldAuctionInfo     string = "auction_info"
+	TransactionFieldCartInfo        string = "cart_info"
+	TransactionFieldIncentiveInfo   string = "incentive_info"
+	TransactionFieldStoreInfo       string = "store_info"
+	TransactionFieldAll             string = "all"
+)
+
+// Values of TransactionSearchRequest.TransactionStatus
+const (
+	TransactionStatusDenied   string = "D"
+	TransactionStatusPending  string = "P"
+	TransactionStatusSuccess  string = "S"
+	TransactionStatusReversed string = "V"
+)
+
+// ErrTransactionSearchRange is returned by ListTransactions for ranges longer
+// than MaxTransactionSearchRange, TransactionIterator splits those
+var ErrTransactionSearchRange = errors.New("paypal: transaction search range exceeds 31 days")
+
+// ListTransactions returns one page of the transactions between StartDate and
+// EndDate, which may be at most 31 days apart. Use IterateTransactions for
+// longer ranges and to walk all pages.
+// Endpoint: GET /v1/reporting/transactions
+func (c *Client) ListTransactions(ctx context.Context, searchRequest *TransactionSearchRequest) (*TransactionSearchResponse, error) {
+	response := &TransactionSearchResponse{}
+
+	q, err := searchRequest.values()
+	if err != nil {
+		return response, err
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/reporting/transactions"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *TransactionSearchRequest) values() (url.Values, error) {
+	if r.StartDate.IsZero() || r.EndDate.IsZero() {
+		return nil, errors.New("paypal: transaction search needs StartDate and EndDate")
+	}
+	if !r.EndDate.After(r.StartDate) {
+		return nil, errors.New("paypal: transaction search EndDate must be after StartDate")
+	}
+	if r.EndDate.Sub(r.StartDate) > MaxTransactionSearchRange {
+		return nil, ErrTransactionSearchRange
+	}
+
+	q := url.Values{}
+	q.Set("start_date", r.StartDate.Format(reportingTimeLayout))
+	q.Set("end_date", r.EndDate.Format(reportingTimeLayout))
+	if r.TransactionID != "" {
+		q.Set("transaction_id", r.TransactionID)
+	}
+	if r.TransactionType != "" {
+		q.Set("transaction_type", r.TransactionType)
+	}
+	if r.TransactionStatus != "" {
+		q.Set("transaction_status", r.TransactionStatus)
+	}
+	if r.TransactionCurrency != "" {
+		q.Set("transaction_currency", r.TransactionCurrency)
+	}
+	if r.MinAmount != "" || r.MaxAmount != "" {
+		amount, err := r.amountRange()
+		if err != nil {
+			return nil, err
+		}
+		q.Set("transaction_amount", amount)
+	}
+	if r.PaymentInstrumentType != "" {
+		q.Set("payment_instrument_type", r.PaymentInstrumentType)
+	}
+	if r.BalanceAffectingRecordsOnly {
+		q.Set("balance_affecting_records_only", "Y")
+	}
+	if len(r.Fields) > 0 {
+		q.Set("fields", strings.Join(r.Fields, ","))
+	}
+	if r.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(r.PageSize))
+	}
+	if r.Page > 0 {
+		q.Set("page", strconv.Itoa(r.Page))
+	}
+	return q, nil
+}
+
+// amountRange formats MinAmount and MaxAmount as PayPal's "<min> TO <max>" in minor units
+func (r *TransactionSearchRequest) amountRange() (string, error) {
+	if r.TransactionCurrency == "" {
+		return "", errors.New("paypal: transaction amount filter needs TransactionCurrency")
+	}
+	bounds := []string{r.MinAmount, r.MaxAmount}
+	minor := make([]int64, 2)
+	for i, bound := range bounds {
+		if bound == "" {
+			continue
+		}
+		v, err := ParseMoney(r.TransactionCurrency, bound)
+		if err != nil {
+			return "", err
+		}
+		minor[i] = v.MinorUnits()
+	}
+	if r.MaxAmount == "" {
+		minor[1] = 1<<63 - 1
+	}
+	return fmt.Sprintf("%d TO %d", minor[0], minor[1]), nil
+}
+
+// TransactionSearchWindows splits [start, end] into consecutive windows no
+// longer than MaxTransactionSearchRange, the last one ending at end. A window
+// starts one second after the previous one ended, since PayPal includes both
+// ends of a range and only knows whole seconds.
+func TransactionSearchWindows(start, end time.Time) [][2]time.Time {
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	var windows [][2]time.Time
+	for start.Before(end) {
+		windowEnd := start.Add(MaxTransactionSearchRange)
+		if !windowEnd.Before(end) {
+			windowEnd = end
+		} else if end.Sub(windowEnd) == time.Second {
+			// The next window would start at end, which can't be searched
+			// alone, so leave it two seconds
+			windowEnd = windowEnd.Add(-time.Second)
+		}
+		windows = append(windows, [2]time.Time{start, windowEnd})
+		start = windowEnd.Add(time.Second)
+	}
+	return windows
+}
+
+// TransactionIterator walks every transaction of a search across its 31 day
+// windows and pages:
+//
+//	it := c.IterateTransactions(paypal.TransactionSearchRequest{StartDate: from, EndDate: to})
+//	for it.Next(ctx) {
+//		tx := it.Transaction()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type TransactionIterator struct {
+	client  *Client
+	request TransactionSearchRequest
+	windows [][2]time.Time
+
+	window  int
+	page    *TransactionSearchResponse
+	index   int
+	current *SearchTransactionDetails
+	err     error
+}
+
+// IterateTransactions returns an iterator over all transactions matching
+// searchRequest, whose date range may be longer than 31 days. Page is ignored.
+func (c *Client) IterateTransactions(searchRequest TransactionSearchRequest) *TransactionIterator {
+	it := &TransactionIterator{client: c, request: searchRequest}
+	if searchRequest.StartDate.IsZero() || searchRequest.EndDate.IsZero() {
+		it.err = errors.New("paypal: transaction search needs StartDate and EndDate")
+		return it
+	}
+	if it.request.PageSize <= 0 {
+		it.request.PageSize = MaxTransactionSearchPageSize
+	}
+	it.windows = TransactionSearchWindows(searchRequest.StartDate, searchRequest.EndDate)
+	return it
+}
+
+// Next advances to the next transaction, fetching pages as needed. It returns
+// false when all transactions were read or an error occurred, see Err.
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for {
+		if it.page != nil && it.index < len(it.page.TransactionDetails) {
+			it.current = &it.page.TransactionDetails[it.index]
+			it.index++
+			return true
+		}
+
+		var pageNumber int
+		switch {
+		case it.page != nil && it.page.Page < it.page.TotalPages:
+			pageNumber = it.page.Page + 1
+		case it.page != nil:
+			it.window++
+			pageNumber = 1
+		default:
+			pageNumber = 1
+		}
+		if it.window >= len(it.windows) {
+			it.current = nil
+			return false
+		}
+
+		request := it.request
+		request.StartDate, request.EndDate = it.windows[it.window][0], it.windows[it.window][1]
+		request.Page = pageNumber
+		page, err := it.client.ListTransactions(ctx, &request)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		if page.Page == 0 {
+			page.Page = pageNumber
+		}
+		it.page, it.index = page, 0
+	}
+}
+
+// Transaction returns the transaction Next advanced to
+func (it *TransactionIterator) Transaction() *SearchTransactionDetails {
+	return it.current
+}
+
+// Page returns the page the current transaction belongs to
+func (it *TransactionIterator) Page() *TransactionSearchResponse {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+// ListBalances returns the balance of every currency held by the account
+// Endpoint: GET /v1/reporting/balances
+func (c *Client) ListBalances(ctx context.Context, params *ListBalancesParams) (*ListBalancesResponse, error) {
+	response := &ListBalancesResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		if !params.AsOfTime.IsZero() {
+			q.Set("as_of_time", params.AsOfTime.Format(reportingTimeLayout))
+		}
+		if params.CurrencyCode != "" {
+			q.Set("currency_code", params.CurrencyCode)
+		}
+		if params.IncludeCryptoCurrencies {
+			q.Set("include_crypto_currencies", "true")
+		}
+	}
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/reporting/balances"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Balance returns the balance of currency, or nil when the account holds none
+func (r *ListBalancesResponse) Balance(currency string) *Balance {
+	for i := range r.Balances {
+		if r.Balances[i].Currency == currency {
+			return &r.Balances[i]
+		}
+	}
+	return nil
+}
+
+// BalanceSnapshot is one reading of SnapshotBalances. Err is set instead of
+// Balances when the reading failed.
+type BalanceSnapshot struct {
+	TakenAt  time.Time
+	Balances *ListBalancesResponse
+	Err      error
+}
+
+// BalanceSink receives the readings of SnapshotBalances, e.g. to store them in
+// a time series. Returning an error stops SnapshotBalances.
+type BalanceSink interface {
+	WriteBalanceSnapshot(ctx context.Context, snapshot *BalanceSnapshot) error
+}
+
+// BalanceSinkFunc adapts a function to a BalanceSink
+type BalanceSinkFunc func(ctx context.Context, snapshot *BalanceSnapshot) error
+
+// WriteBalanceSnapshot calls f
+func (f BalanceSinkFunc) WriteBalanceSnapshot(ctx context.Context, snapshot *BalanceSnapshot) error {
+	return f(ctx, snapshot)
+}
+
+// SnapshotBalances reads the balances right away and then every interval,
+// writing each reading to sink. Failed readings are written too, so the sink
+// decides whether to give up. It blocks until ctx is done or sink returns an
+// error, run it in its own goroutine.
+func (c *Client) SnapshotBalances(ctx context.Context, interval time.Duration, params *ListBalancesParams, sink BalanceSink) error {
+	if interval <= 0 {
+		return errors.New("paypal: balance snapshot interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		snapshot := &BalanceSnapshot{TakenAt: time.Now()}
+		balances, err := c.ListBalances(ctx, params)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			snapshot.Err = err
+		} else {
+			snapshot.Balances = balances
+		}
+		if err = sink.WriteBalanceSnapshot(ctx, snapshot); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package paypal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// transactionsPage is a /v1/reporting/transactions page after the sample
+// response of PayPal's transaction search docs, note the timezone offsets
+// without a colon
+const transactionsPage = `{
+  "transaction_details": [
+    {
+      "transaction_info": {
+        "paypal_account_id": "6STWC2LSUYYYE",
+        "transaction_id": "5TY05013RG002845M",
+        "transaction_event_code": "T0006",
+        "transaction_initiation_date": "2021-07-07T17:53:00+0000",
+        "transaction_updated_date": "2021-07-07T17:53:00+0000",
+        "transaction_amount": {"currency_code": "USD", "value": "465.00"},
+        "fee_amount": {"currency_code": "USD", "value": "-13.79"},
+        "insurance_amount": {"currency_code": "USD", "value": "15.00"},
+        "shipping_amount": {"currency_code": "USD", "value": "30.00"},
+        "shipping_discount_amount": {"currency_code": "USD", "value": "10.00"},
+        "transaction_status": "S",
+        "transaction_subject": "Bill for your purchase",
+        "transaction_note": "Check out the latest sales",
+        "invoice_id": "Invoice-005",
+        "custom_field": "Thank you for your business",
+        "protection_eligibility": "01"
+      },
+      "payer_info": {
+        "account_id": "6STWC2LSUYYYE",
+        "email_address": "consumer@example.com",
+        "address_status": "Y",
+        "payer_status": "Y",
+        "payer_name": {"given_name": "test", "surname": "consumer", "alternate_full_name": "test consumer"},
+        "country_code": "US"
+      },
+      "shipping_info": {
+        "name": "Sowmith",
+        "address": {"line1": "Eco Space, bellandur", "line2": "OuterRingRoad", "city": "Bangalore", "country_code": "IN", "postal_code": "560103"}
+      },
+      "cart_info": {
+        "item_details": [
+          {
+            "item_code": "ItemCode-1",
+            "item_name": "Item1 - radio",
+            "item_description": "Radio",
+            "item_quantity": "2",
+            "item_unit_price": {"currency_code": "USD", "value": "50.00"},
+            "item_amount": {"currency_code": "USD", "value": "100.00"},
+            "tax_amounts": [{"tax_amount": {"currency_code": "USD", "value": "20.00"}}],
+            "total_item_amount": {"currency_code": "USD", "value": "120.00"},
+            "invoice_number": "Invoice-005"
+          }
+        ],
+        "tax_inclusive": false
+      }
+    },
+    {
+      "transaction_info": {
+        "paypal_account_id": "6STWC2LSUYYYE",
+        "transaction_id": "8LP28263UK930284H",
+        "transaction_event_code": "T1107",
+        "transaction_initiation_date": "2021-07-08T02:10:41-0700",
+        "transaction_updated_date": "2021-07-08T02:10:41-0700",
+        "transaction_amount": {"currency_code": "USD", "value": "-20.00"},
+        "transaction_status": "S",
+        "protection_eligibility": "02"
+      }
+    }
+  ],
+  "account_number": "XZXSPECPDZHZU",
+  "start_date": "2021-07-01T00:00:00+0000",
+  "end_date": "2021-07-30T23:59:59+0000",
+  "last_refreshed_datetime": "2021-07-31T04:29:59+0000",
+  "page": 1,
+  "total_items": 2,
+  "total_pages": 1,
+  "links": [
+    {"href": "https://api-m.sandbox.paypal.com/v1/reporting/transactions?start_date=2021-07-01T00%3A00%3A00%2B0000&end_date=2021-07-30T23%3A59%3A59%2B0000&fields=all&page_size=100&page=1", "rel": "self", "method": "GET"}
+  ]
+}`
+
+func TestListTransactions(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/oauth2/token" {
+			w.Write([]byte(`{"access_token":"A21AAF","token_type":"Bearer","expires_in":32400}`))
+			return
+		}
+		if r.URL.Path != "/v1/reporting/transactions" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query()
+		w.Write([]byte(transactionsPage))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("clientID", "secret", WithAPIBase(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TokenStore = nil
+
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	page, err := c.ListTransactions(context.Background(), &TransactionSearchRequest{
+		StartDate:           start,
+		EndDate:             start.Add(30*24*time.Hour - time.Second),
+		TransactionCurrency: "USD",
+		MinAmount:           "5.00",
+		Fields:              []string{TransactionFieldAll},
+		PageSize:            100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantQuery := map[string]string{
+		"start_date":           "2021-07-01T00:00:00+0000",
+		"end_date":             "2021-07-30T23:59:59+0000",
+		"transaction_currency": "USD",
+		"transaction_amount":   "500 TO 9223372036854775807",
+		"fields":               "all",
+		"page_size":            "100",
+	}
+	for k, v := range wantQuery {
+		if got := query.Get(k); got != v {
+			t.Errorf("query %s = %q, want %q", k, got, v)
+		}
+	}
+
+	if page.TotalItems != 2 || page.TotalPages != 1 || len(page.TransactionDetails) != 2 {
+		t.Fatalf("page %d/%d with %d transactions", page.Page, page.TotalPages, len(page.TransactionDetails))
+	}
+	if !page.StartDate.Time().Equal(start) || page.LastRefreshedDatetime.IsZero() {
+		t.Errorf("start_date %s, last_refreshed_datetime %s", page.StartDate, page.LastRefreshedDatetime)
+	}
+
+	sale := page.TransactionDetails[0]
+	if sale.TransactionInfo.TransactionID != "5TY05013RG002845M" || sale.TransactionInfo.TransactionAmount.Value != "465.00" {
+		t.Errorf("transaction_info %+v", sale.TransactionInfo)
+	}
+	if want := time.Date(2021, 7, 7, 17, 53, 0, 0, time.UTC); !sale.TransactionInfo.TransactionInitiationDate.Time().Equal(want) {
+		t.Errorf("transaction_initiation_date %s, want %s", sale.TransactionInfo.TransactionInitiationDate, want)
+	}
+	if sale.TransactionInfo.FeeAmount == nil || sale.TransactionInfo.FeeAmount.Value != "-13.79" {
+		t.Errorf("fee_amount %+v", sale.TransactionInfo.FeeAmount)
+	}
+	if sale.PayerInfo == nil || sale.PayerInfo.EmailAddress != "consumer@example.com" {
+		t.Errorf("payer_info %+v", sale.PayerInfo)
+	}
+	if sale.ShippingInfo == nil || sale.ShippingInfo.Address.PostalCode != "560103" {
+		t.Errorf("shipping_info %+v", sale.ShippingInfo)
+	}
+	if sale.CartInfo == nil || len(sale.CartInfo.ItemDetails) != 1 || sale.CartInfo.TaxInclusive == nil || *sale.CartInfo.TaxInclusive {
+		t.Errorf("cart_info %+v", sale.CartInfo)
+	}
+
+	refund := page.TransactionDetails[1]
+	if want := time.Date(2021, 7, 8, 9, 10, 41, 0, time.UTC); !refund.TransactionInfo.TransactionInitiationDate.Time().Equal(want) {
+		t.Errorf("-0700 transaction_initiation_date %s, want %s", refund.TransactionInfo.TransactionInitiationDate, want)
+	}
+	if refund.PayerInfo != nil || refund.CartInfo != nil {
+		t.Errorf("absent sections decoded: %+v", refund)
+	}
+}
+
+func TestTransactionSearchRequestValues(t *testing.T) {
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		req  TransactionSearchRequest
+	}{
+		{"no dates", TransactionSearchRequest{}},
+		{"end before start", TransactionSearchRequest{StartDate: start, EndDate: start.Add(-time.Hour)}},
+		{"range too long", TransactionSearchRequest{StartDate: start, EndDate: start.Add(MaxTransactionSearchRange + time.Second)}},
+		{"amount without currency", TransactionSearchRequest{StartDate: start, EndDate: start.Add(time.Hour), MinAmount: "5.00"}},
+		{"amount precision", TransactionSearchRequest{StartDate: start, EndDate: start.Add(time.Hour), TransactionCurrency: "JPY", MinAmount: "5.00"}},
+	}
+	for _, tt := range tests {
+		if _, err := tt.req.values(); err == nil {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+}
+
+func TestTransactionSearchWindows(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		end   time.Time
+		count int
+	}{
+		{"one day", start.Add(24 * time.Hour), 1},
+		{"exactly the max range", start.Add(MaxTransactionSearchRange), 1},
+		{"one second more", start.Add(MaxTransactionSearchRange + time.Second), 2},
+		{"two seconds more", start.Add(MaxTransactionSearchRange + 2*time.Second), 2},
+		{"half a second more", start.Add(MaxTransactionSearchRange + time.Second/2), 1},
+		{"one and a half seconds more", start.Add(MaxTransactionSearchRange + 3*time.Second/2), 2},
+		{"two ranges and a second", start.Add(2*MaxTransactionSearchRange + 2*time.Second), 3},
+		{"a year", start.AddDate(1, 0, 0), 12},
+	}
+	for _, tt := range tests {
+		windows := TransactionSearchWindows(start, tt.end)
+		if len(windows) != tt.count {
+			t.Errorf("%s: %d windows, want %d", tt.name, len(windows), tt.count)
+			continue
+		}
+		if !windows[0][0].Equal(start) || !windows[len(windows)-1][1].Equal(tt.end.Truncate(time.Second)) {
+			t.Errorf("%s: windows span %s to %s, want %s to %s", tt.name, windows[0][0], windows[len(windows)-1][1], start, tt.end)
+		}
+		for i, window := range windows {
+			req := TransactionSearchRequest{StartDate: window[0], EndDate: window[1]}
+			if _, err := req.values(); err != nil {
+				t.Errorf("%s: window %d %s to %s: %v", tt.name, i, window[0], window[1], err)
+			}
+			if i > 0 && !window[0].Equal(windows[i-1][1].Add(time.Second)) {
+				t.Errorf("%s: window %d starts at %s, %s after the previous end", tt.name, i, window[0], window[0].Sub(windows[i-1][1]))
+			}
+		}
+	}
+
+	if windows := TransactionSearchWindows(start, start); len(windows) != 0 {
+		t.Errorf("empty range split into %v", windows)
+	}
+}
+
+const balancesResponse = `{
+  "balances": [
+    {"currency": "USD", "primary": true, "total_balance": {"currency_code": "USD", "value": "900.00"}, "available_balance": {"currency_code": "USD", "value": "850.00"}, "withheld_balance": {"currency_code": "USD", "value": "50.00"}},
+    {"currency": "EUR", "total_balance": {"currency_code": "EUR", "value": "12.34"}, "available_balance": {"currency_code": "EUR", "value": "12.34"}, "withheld_balance": {"currency_code": "EUR", "value": "0.00"}}
+  ],
+  "account_id": "3BTVDNQXSNTMN",
+  "as_of_time": "2021-07-31T00:00:00+0000",
+  "last_refresh_time": "2021-07-30T23:59:59+0000"
+}`
+
+func TestListBalances(t *testing.T) {
+	rec := newAPIRecorder(t, balancesResponse)
+	c := rec.client(t, nil)
+
+	asOf := time.Date(2021, 7, 30, 17, 0, 0, 0, time.FixedZone("", -7*60*60))
+	balances, err := c.ListBalances(context.Background(), &ListBalancesParams{AsOfTime: asOf, CurrencyCode: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "GET" || req.Path != "/v1/reporting/balances" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Query.Encode(); got != "as_of_time=2021-07-30T17%3A00%3A00-0700&currency_code=USD" {
+		t.Errorf("query %s", got)
+	}
+
+	if balances.AccountID != "3BTVDNQXSNTMN" || !balances.AsOfTime.Time().Equal(time.Date(2021, 7, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("response %+v", balances)
+	}
+	if usd := balances.Balance("USD"); usd == nil || !usd.Primary || usd.AvailableBalance.Value != "850.00" || usd.WithheldBalance.Value != "50.00" {
+		t.Errorf("USD balance %+v", usd)
+	}
+	if jpy := balances.Balance("JPY"); jpy != nil {
+		t.Errorf("JPY balance %+v", jpy)
+	}
+
+	if _, err = c.ListBalances(context.Background(), &ListBalancesParams{IncludeCryptoCurrencies: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Query.Encode(); got != "include_crypto_currencies=true" {
+		t.Errorf("query %s", got)
+	}
+	if _, err = c.ListBalances(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Query.Encode(); got != "" {
+		t.Errorf("query without params %s", got)
+	}
+}
+
+func TestSnapshotBalances(t *testing.T) {
+	var requests int32
+	srv := newTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// The second reading fails
+		if atomic.AddInt32(&requests, 1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"name":"SERVICE_UNAVAILABLE"}`))
+			return
+		}
+		w.Write([]byte(balancesResponse))
+	})
+	c := srv.client(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var snapshots []*BalanceSnapshot
+	err := c.SnapshotBalances(ctx, time.Millisecond, nil, BalanceSinkFunc(func(ctx context.Context, snapshot *BalanceSnapshot) error {
+		snapshots = append(snapshots, snapshot)
+		if len(snapshots) == 3 {
+			cancel()
+		}
+		return nil
+	}))
+	if err != context.Canceled {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	if len(snapshots) != 3 {
+		t.Fatalf("%d snapshots, want 3", len(snapshots))
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.TakenAt.IsZero() {
+			t.Errorf("snapshot %d without TakenAt", i)
+		}
+		failed := i == 1
+		if failed != (snapshot.Err != nil) || failed != (snapshot.Balances == nil) {
+			t.Errorf("snapshot %d: balances %v, err %v", i, snapshot.Balances, snapshot.Err)
+		}
+	}
+	if !IsStatus(snapshots[1].Err, http.StatusServiceUnavailable) {
+		t.Errorf("failed reading: %v", snapshots[1].Err)
+	}
+	if usd := snapshots[2].Balances.Balance("USD"); usd == nil || usd.TotalBalance.Value != "900.00" {
+		t.Errorf("USD balance %+v", usd)
+	}
+
+	stop := errors.New("sink full")
+	err = c.SnapshotBalances(context.Background(), time.Millisecond, nil, BalanceSinkFunc(func(ctx context.Context, snapshot *BalanceSnapshot) error {
+		return stop
+	}))
+	if err != stop {
+		t.Fatalf("got %v, want the sink error", err)
+	}
+
+	if err = c.SnapshotBalances(context.Background(), 0, nil, BalanceSinkFunc(func(ctx context.Context, snapshot *BalanceSnapshot) error {
+		t.Fatal("sink called without an interval")
+		return nil
+	})); err == nil {
+		t.Fatal("zero interval accepted")
+	}
+}
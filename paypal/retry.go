@@ -0,0 +1,148 @@
+/**
+ * @ClassName retry
+ * @Description retry policy with backoff for transient failures
+ * @Author liwei
+ * @Date 2026/10/18 14:40
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries requests that failed with a connection error, 429 or a
+// 5xx status. POST and PATCH requests are only retried when they carry a
+// PayPal-Request-Id, which makes PayPal process them at most once.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts including the first one, 1 or less disables retries
+	BaseDelay   time.Duration // Backoff before the first retry, doubled for each further retry
+	MaxDelay    time.Duration // Upper bound of a single backoff
+	MaxElapsed  time.Duration // Give up when the next attempt would start later than this after the first, 0 for no limit
+}
+
+// DefaultRetryPolicy makes up to three attempts within 30 seconds
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Duration(500) * time.Millisecond,
+	MaxDelay:    time.Duration(10) * time.Second,
+	MaxElapsed:  time.Duration(30) * time.Second,
+}
+
+// retryable reports whether the outcome of an attempt of req is worth another one
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+	default:
+		if req.Header.Get("PayPal-Request-Id") == "" {
+			return false
+		}
+	}
+
+	if resp == nil {
+		// Cancellation by the caller is final, anything else is a transport failure
+		return err != nil && req.Context().Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// backoff returns the delay before attempt+1: Retry-After when the server sent
+// one, exponential backoff with full jitter otherwise. Both are capped at MaxDelay.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// allows reports whether waiting delay still fits in MaxElapsed and the context deadline
+func (p *RetryPolicy) allows(ctx context.Context, start time.Time, delay time.Duration) bool {
+	next := time.Now().Add(delay)
+	if p.MaxElapsed > 0 && next.Sub(start) > p.MaxElapsed {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && !next.Before(deadline) {
+		return false
+	}
+	return true
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// do sends req through the middleware chain, retrying it according to
+// c.Retry. The body of req is rewound with GetBody before every retry.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	roundTrip := c.chain()
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := roundTrip(req)
+
+		policy := c.Retry
+		if policy == nil || attempt >= policy.MaxAttempts || !policy.retryable(req, resp, err) {
+			return resp, err
+		}
+		delay := policy.backoff(attempt, resp)
+		if !policy.allows(req.Context(), start, delay) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
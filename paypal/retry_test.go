@@ -0,0 +1,180 @@
+package paypal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer answers every request with the statuses in order, repeating
+// the last one, and counts the attempts it received
+type countingServer struct {
+	*httptest.Server
+	attempts int32
+}
+
+func newCountingServer(t *testing.T, header http.Header, statuses ...int) *countingServer {
+	t.Helper()
+	s := &countingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&s.attempts, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *countingServer) send(t *testing.T, ctx context.Context, policy *RetryPolicy, method, requestID string) error {
+	t.Helper()
+	c, err := NewClient("clientID", "secret", WithAPIBase(s.URL), WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload interface{}
+	if method == "POST" || method == "PATCH" || method == "PUT" {
+		payload = map[string]string{"intent": "CAPTURE"}
+	}
+	req, err := c.NewRequest(ctx, method, s.URL+"/v2/checkout/orders", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+	return c.Send(req, &struct{}{})
+}
+
+func TestRetryPolicyIdempotency(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	tests := []struct {
+		method    string
+		requestID string
+		want      int32
+	}{
+		{"GET", "", 3},
+		{"DELETE", "", 3},
+		{"PUT", "", 3},
+		{"POST", "", 1},
+		{"PATCH", "", 1},
+		{"POST", "7b92603e-77ed-4896-8e78-5dea2050476a", 3},
+		{"PATCH", "7b92603e-77ed-4896-8e78-5dea2050476a", 3},
+	}
+	for _, tt := range tests {
+		srv := newCountingServer(t, nil, http.StatusServiceUnavailable)
+		err := srv.send(t, context.Background(), policy, tt.method, tt.requestID)
+		if !IsStatus(err, http.StatusServiceUnavailable) {
+			t.Errorf("%s with request id %q: got %v, want 503", tt.method, tt.requestID, err)
+		}
+		if got := atomic.LoadInt32(&srv.attempts); got != tt.want {
+			t.Errorf("%s with request id %q: %d attempts, want %d", tt.method, tt.requestID, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyStatuses(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	tests := []struct {
+		statuses []int
+		want     int32
+		wantErr  bool
+	}{
+		{[]int{http.StatusInternalServerError, http.StatusOK}, 2, false},
+		{[]int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}, 3, false},
+		{[]int{http.StatusBadRequest}, 1, true},
+		{[]int{http.StatusUnprocessableEntity}, 1, true},
+		{[]int{http.StatusBadGateway}, 3, true},
+	}
+	for _, tt := range tests {
+		srv := newCountingServer(t, nil, tt.statuses...)
+		err := srv.send(t, context.Background(), policy, "GET", "")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: got %v", tt.statuses, err)
+		}
+		if got := atomic.LoadInt32(&srv.attempts); got != tt.want {
+			t.Errorf("%v: %d attempts, want %d", tt.statuses, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffRetryAfter(t *testing.T) {
+	resp := func(retryAfter string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second}
+
+	if d := policy.backoff(1, resp("7")); d != 7*time.Second {
+		t.Errorf("Retry-After 7: waited %s", d)
+	}
+	if d := policy.backoff(1, resp("120")); d != 10*time.Second {
+		t.Errorf("Retry-After 120: waited %s, want MaxDelay", d)
+	}
+	if d := policy.backoff(1, resp(time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat))); d <= 3*time.Second || d > 5*time.Second {
+		t.Errorf("Retry-After date: waited %s", d)
+	}
+	if d := policy.backoff(1, resp("soon")); d > time.Millisecond {
+		t.Errorf("bad Retry-After: waited %s, want the base backoff", d)
+	}
+}
+
+func TestRetryPolicyRetryAfterCapped(t *testing.T) {
+	header := http.Header{"Retry-After": {"120"}}
+	srv := newCountingServer(t, header, http.StatusTooManyRequests, http.StatusOK)
+	policy := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	start := time.Now()
+	if err := srv.send(t, context.Background(), policy, "GET", ""); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 5*time.Second {
+		t.Fatalf("took %s, want about MaxDelay", elapsed)
+	}
+	if got := atomic.LoadInt32(&srv.attempts); got != 2 {
+		t.Fatalf("%d attempts, want 2", got)
+	}
+}
+
+func TestRetryPolicyStopsAtDeadline(t *testing.T) {
+	// Retry-After pins the backoff, so the outcome doesn't depend on the jitter
+	srv := newCountingServer(t, http.Header{"Retry-After": {"1"}}, http.StatusServiceUnavailable)
+	policy := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := srv.send(t, ctx, policy, "GET", "")
+	if !IsStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("got %v, want the 503 of the only attempt", err)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("waited %s for a retry past the deadline", elapsed)
+	}
+	if got := atomic.LoadInt32(&srv.attempts); got != 1 {
+		t.Fatalf("%d attempts, want 1", got)
+	}
+}
+
+func TestRetryPolicyStopsAtMaxElapsed(t *testing.T) {
+	srv := newCountingServer(t, http.Header{"Retry-After": {"1"}}, http.StatusServiceUnavailable)
+	policy := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second, MaxElapsed: 500 * time.Millisecond}
+
+	if err := srv.send(t, context.Background(), policy, "GET", ""); !IsStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("got %v, want 503", err)
+	}
+	if got := atomic.LoadInt32(&srv.attempts); got != 1 {
+		t.Fatalf("%d attempts, want 1", got)
+	}
+}
@@ -0,0 +1,177 @@
+/**
+ * @ClassName subscription
+ * @Description subscriptions v1 lifecycle
+ * @Author liwei
+ * @Date 2026/10/18 21:00
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// CreateSubscription subscribes a payer to a plan. The subscription stays
+// APPROVAL_PENDING until the payer follows its "approve" link.
+// Endpoint: POST /v1/billing/subscriptions
+func (c *Client) CreateSubscription(ctx context.Context, subscription SubscriptionBase) (*Subscription, error) {
+	return c.CreateSubscriptionWithPaypalRequestID(ctx, subscription, "")
+}
+
+// CreateSubscriptionWithPaypalRequestID - Use this call to create a subscription with idempotency
+// Endpoint: POST /v1/billing/subscriptions
+func (c *Client) CreateSubscriptionWithPaypalRequestID(ctx context.Context, subscription SubscriptionBase, requestID string) (*Subscription, error) {
+	response := &Subscription{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/billing/subscriptions"), subscription)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetSubscription returns a subscription with its billing info
+// Endpoint: GET /v1/billing/subscriptions/ID
+func (c *Client) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	response := &Subscription{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/billing/subscriptions/", subscriptionID), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ReviseSubscription changes the plan or quantity of a subscription. The
+// subscriber approves the change through the "approve" link of the response.
+// Endpoint: POST /v1/billing/subscriptions/ID/revise
+func (c *Client) ReviseSubscription(ctx context.Context, subscriptionID string, revise ReviseSubscriptionRequest) (*ReviseSubscriptionResponse, error) {
+	response := &ReviseSubscriptionResponse{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/subscriptions/", subscriptionID, "/revise"), revise)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// SuspendSubscription pauses billing of an ACTIVE subscription
+// Endpoint: POST /v1/billing/subscriptions/ID/suspend
+func (c *Client) SuspendSubscription(ctx context.Context, subscriptionID, reason string) error {
+	return c.changeSubscriptionStatus(ctx, subscriptionID, "/suspend", reason)
+}
+
+// ActivateSubscription resumes a SUSPENDED subscription
+// Endpoint: POST /v1/billing/subscriptions/ID/activate
+func (c *Client) ActivateSubscription(ctx context.Context, subscriptionID, reason string) error {
+	return c.changeSubscriptionStatus(ctx, subscriptionID, "/activate", reason)
+}
+
+// CancelSubscription ends a subscription for good
+// Endpoint: POST /v1/billing/subscriptions/ID/cancel
+func (c *Client) CancelSubscription(ctx context.Context, subscriptionID, reason string) error {
+	return c.changeSubscriptionStatus(ctx, subscriptionID, "/cancel", reason)
+}
+
+func (c *Client) changeSubscriptionStatus(ctx context.Context, subscriptionID, action, reason string) error {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/subscriptions/", subscriptionID, action),
+		SubscriptionStatusChangeRequest{Reason: reason})
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// CaptureSubscriptionPayment charges the outstanding balance of a subscription,
+// e.g. after failed payments. The returned transaction is empty when PayPal
+// only accepted the capture.
+// Endpoint: POST /v1/billing/subscriptions/ID/capture
+func (c *Client) CaptureSubscriptionPayment(ctx context.Context, subscriptionID string, captureRequest CaptureSubscriptionPaymentRequest) (*SubscriptionTransaction, error) {
+	return c.CaptureSubscriptionPaymentWithPaypalRequestID(ctx, subscriptionID, captureRequest, "")
+}
+
+// CaptureSubscriptionPaymentWithPaypalRequestID - Use this call to capture a
+// subscription payment with idempotency, a retry never charges twice
+// Endpoint: POST /v1/billing/subscriptions/ID/capture
+func (c *Client) CaptureSubscriptionPaymentWithPaypalRequestID(ctx context.Context, subscriptionID string, captureRequest CaptureSubscriptionPaymentRequest, requestID string) (*SubscriptionTransaction, error) {
+	response := &SubscriptionTransaction{}
+
+	if captureRequest.CaptureType == "" {
+		captureRequest.CaptureType = CaptureTypeOutstandingBalance
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/subscriptions/", subscriptionID, "/capture"), captureRequest)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ListSubscriptionTransactions returns the payments of a subscription between startTime and endTime
+// Endpoint: GET /v1/billing/subscriptions/ID/transactions
+func (c *Client) ListSubscriptionTransactions(ctx context.Context, subscriptionID string, startTime, endTime time.Time) (*SubscriptionTransactionsResponse, error) {
+	response := &SubscriptionTransactionsResponse{}
+
+	q := url.Values{}
+	q.Set("start_time", startTime.UTC().Format(time.RFC3339))
+	q.Set("end_time", endTime.UTC().Format(time.RFC3339))
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/subscriptions/", subscriptionID, "/transactions"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Link returns the HATEOAS link with the given rel, e.g. "approve"
+func (s *Subscription) Link(rel string) *Link {
+	for i := range s.Links {
+		if s.Links[i].Rel == rel {
+			return &s.Links[i]
+		}
+	}
+	return nil
+}
+
+// IsBillable reports whether PayPal bills the subscription
+func (s *Subscription) IsBillable() bool {
+	return s.Status == SubscriptionStatusActive
+}
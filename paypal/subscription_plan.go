@@ -0,0 +1,228 @@
+/**
+ * @ClassName subscription_plan
+ * @Description subscriptions v1 billing plans
+ * @Author liwei
+ * @Date 2026/10/18 20:10
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// CreateSubscriptionPlan creates a billing plan, ACTIVE unless plan.Status says otherwise
+// Endpoint: POST /v1/billing/plans
+func (c *Client) CreateSubscriptionPlan(ctx context.Context, plan SubscriptionPlan) (*CreateSubscriptionPlanResponse, error) {
+	return c.CreateSubscriptionPlanWithPaypalRequestID(ctx, plan, "")
+}
+
+// CreateSubscriptionPlanWithPaypalRequestID - Use this call to create a plan with idempotency
+// Endpoint: POST /v1/billing/plans
+func (c *Client) CreateSubscriptionPlanWithPaypalRequestID(ctx context.Context, plan SubscriptionPlan, requestID string) (*CreateSubscriptionPlanResponse, error) {
+	response := &CreateSubscriptionPlanResponse{}
+
+	if err := plan.Validate(); err != nil {
+		return response, err
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/billing/plans"), plan)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ListSubscriptionPlans returns one page of billing plans, params may be nil
+// Endpoint: GET /v1/billing/plans
+func (c *Client) ListSubscriptionPlans(ctx context.Context, params *SubscriptionPlanListParameters) (*ListSubscriptionPlansResponse, error) {
+	response := &ListSubscriptionPlansResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		if params.ProductID != "" {
+			q.Set("product_id", params.ProductID)
+		}
+		if params.PlanIDs != "" {
+			q.Set("plan_ids", params.PlanIDs)
+		}
+		params.ListParams.setQuery(q)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/billing/plans"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetSubscriptionPlan returns a billing plan
+// Endpoint: GET /v1/billing/plans/ID
+func (c *Client) GetSubscriptionPlan(ctx context.Context, planID string) (*CreateSubscriptionPlanResponse, error) {
+	response := &CreateSubscriptionPlanResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/billing/plans/", planID), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// UpdateSubscriptionPlan patches the description, payment_preferences or taxes of a plan
+// Endpoint: PATCH /v1/billing/plans/ID
+func (c *Client) UpdateSubscriptionPlan(ctx context.Context, planID string, operations []PatchOperation) error {
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.Domain, "/v1/billing/plans/", planID), operations)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// ActivateSubscriptionPlan lets new subscribers sign up to a plan
+// Endpoint: POST /v1/billing/plans/ID/activate
+func (c *Client) ActivateSubscriptionPlan(ctx context.Context, planID string) error {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/plans/", planID, "/activate"), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// DeactivateSubscriptionPlan stops new sign ups, existing subscriptions keep running
+// Endpoint: POST /v1/billing/plans/ID/deactivate
+func (c *Client) DeactivateSubscriptionPlan(ctx context.Context, planID string) error {
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/plans/", planID, "/deactivate"), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// UpdateSubscriptionPlanPricing replaces the pricing of billing cycles, active
+// subscriptions are charged the new price from their next cycle on
+// Endpoint: POST /v1/billing/plans/ID/update-pricing-schemes
+func (c *Client) UpdateSubscriptionPlanPricing(ctx context.Context, planID string, pricingSchemes []PricingSchemeUpdate) error {
+	for i := range pricingSchemes {
+		if err := pricingSchemes[i].PricingScheme.Validate(); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/billing/plans/", planID, "/update-pricing-schemes"),
+		UpdatePricingSchemesRequest{PricingSchemes: pricingSchemes})
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// Validate checks the billing cycles of a plan the way PayPal does: at most
+// two trials and exactly one regular cycle, sequenced from 1 with trials first,
+// and a valid pricing scheme on every cycle that isn't a free trial
+func (p *SubscriptionPlan) Validate() error {
+	if p.ProductID == "" || p.Name == "" {
+		return errors.New("paypal: plan needs ProductID and Name")
+	}
+	if p.PaymentPreferences == nil {
+		return errors.New("paypal: plan needs PaymentPreferences")
+	}
+
+	var trials, regulars int
+	for i, cycle := range p.BillingCycles {
+		if cycle.Sequence != i+1 {
+			return fmt.Errorf("paypal: billing cycle %d has sequence %d, cycles must be sequenced from 1", i, cycle.Sequence)
+		}
+		if cycle.Frequency.IntervalUnit == "" {
+			return fmt.Errorf("paypal: billing cycle %d needs Frequency.IntervalUnit", cycle.Sequence)
+		}
+		switch cycle.TenureType {
+		case TenureTypeTrial:
+			if regulars > 0 {
+				return fmt.Errorf("paypal: trial billing cycle %d follows the regular cycle", cycle.Sequence)
+			}
+			if cycle.TotalCycles < 1 {
+				return fmt.Errorf("paypal: trial billing cycle %d needs TotalCycles", cycle.Sequence)
+			}
+			trials++
+		case TenureTypeRegular:
+			if cycle.PricingScheme == nil {
+				return fmt.Errorf("paypal: regular billing cycle %d needs a PricingScheme", cycle.Sequence)
+			}
+			regulars++
+		default:
+			return fmt.Errorf("paypal: billing cycle %d has unknown tenure type %q", cycle.Sequence, cycle.TenureType)
+		}
+		if cycle.PricingScheme != nil {
+			if err := cycle.PricingScheme.Validate(); err != nil {
+				return fmt.Errorf("paypal: billing cycle %d: %w", cycle.Sequence, err)
+			}
+		}
+	}
+	if trials > 2 {
+		return errors.New("paypal: plan has more than two trial billing cycles")
+	}
+	if regulars != 1 {
+		return errors.New("paypal: plan needs exactly one regular billing cycle")
+	}
+	return nil
+}
+
+// Validate checks that a pricing scheme is either a fixed price or tiers in a
+// single currency, of which only the last one is open ended
+func (s *PricingScheme) Validate() error {
+	if s.PricingModel == "" {
+		if s.FixedPrice == nil || len(s.Tiers) > 0 {
+			return errors.New("paypal: pricing scheme without PricingModel needs a FixedPrice and no Tiers")
+		}
+		_, err := s.FixedPrice.MoneyValue()
+		return err
+	}
+	if s.PricingModel != PricingModelVolume && s.PricingModel != PricingModelTiered {
+		return fmt.Errorf("paypal: unknown pricing model %q", s.PricingModel)
+	}
+	if s.FixedPrice != nil || len(s.Tiers) == 0 {
+		return fmt.Errorf("paypal: %s pricing scheme needs Tiers and no FixedPrice", s.PricingModel)
+	}
+
+	currency := s.Tiers[0].Amount.Currency
+	for i, tier := range s.Tiers {
+		if _, err := tier.Amount.MoneyValue(); err != nil {
+			return fmt.Errorf("paypal: pricing tier %d: %w", i, err)
+		}
+		if tier.Amount.Currency != currency {
+			return fmt.Errorf("paypal: pricing tier %d: %w", i, ErrCurrencyMismatch)
+		}
+		last := i == len(s.Tiers)-1
+		if tier.EndingQuantity == "" && !last {
+			return fmt.Errorf("paypal: pricing tier %d needs EndingQuantity, only the last tier is open ended", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package paypal
+
+import (
+	"errors"
+	"testing"
+)
+
+func monthlyCycle(sequence int, tenureType string, scheme *PricingScheme) BillingCycle {
+	cycle := BillingCycle{
+		PricingScheme: scheme,
+		Frequency:     Frequency{IntervalUnit: "MONTH", IntervalCount: 1},
+		TenureType:    tenureType,
+		Sequence:      sequence,
+	}
+	if tenureType == TenureTypeTrial {
+		cycle.TotalCycles = 1
+	}
+	return cycle
+}
+
+func fixedPrice(value string) *PricingScheme {
+	return &PricingScheme{FixedPrice: &Money{Currency: "USD", Value: value}}
+}
+
+func TestSubscriptionPlanValidate(t *testing.T) {
+	regular := monthlyCycle(1, TenureTypeRegular, fixedPrice("10.00"))
+	freeTrial := monthlyCycle(1, TenureTypeTrial, nil)
+	paidTrial := monthlyCycle(2, TenureTypeTrial, fixedPrice("5.00"))
+	unlimitedTrial := freeTrial
+	unlimitedTrial.TotalCycles = 0
+	noUnit := regular
+	noUnit.Frequency.IntervalUnit = ""
+
+	tests := []struct {
+		name   string
+		cycles []BillingCycle
+		valid  bool
+	}{
+		{"regular only", []BillingCycle{regular}, true},
+		{"free trial", []BillingCycle{freeTrial, monthlyCycle(2, TenureTypeRegular, fixedPrice("10.00"))}, true},
+		{"free and paid trial", []BillingCycle{freeTrial, paidTrial, monthlyCycle(3, TenureTypeRegular, fixedPrice("10.00"))}, true},
+		{"no cycles", nil, false},
+		{"sequence from 0", []BillingCycle{monthlyCycle(0, TenureTypeRegular, fixedPrice("10.00"))}, false},
+		{"sequence gap", []BillingCycle{freeTrial, monthlyCycle(3, TenureTypeRegular, fixedPrice("10.00"))}, false},
+		{"trial after regular", []BillingCycle{regular, monthlyCycle(2, TenureTypeTrial, nil)}, false},
+		{"three trials", []BillingCycle{freeTrial, paidTrial, monthlyCycle(3, TenureTypeTrial, nil), monthlyCycle(4, TenureTypeRegular, fixedPrice("10.00"))}, false},
+		{"two regular cycles", []BillingCycle{regular, monthlyCycle(2, TenureTypeRegular, fixedPrice("12.00"))}, false},
+		{"trial only", []BillingCycle{freeTrial}, false},
+		{"trial without total cycles", []BillingCycle{unlimitedTrial, monthlyCycle(2, TenureTypeRegular, fixedPrice("10.00"))}, false},
+		{"regular without price", []BillingCycle{monthlyCycle(1, TenureTypeRegular, nil)}, false},
+		{"without interval unit", []BillingCycle{noUnit}, false},
+		{"unknown tenure type", []BillingCycle{monthlyCycle(1, "EVERGREEN", fixedPrice("10.00"))}, false},
+		{"invalid price", []BillingCycle{monthlyCycle(1, TenureTypeRegular, fixedPrice("10.001"))}, false},
+	}
+	for _, tt := range tests {
+		plan := SubscriptionPlan{
+			ProductID:          "PROD-XXCD1234QWER65782",
+			Name:               "Video Streaming Service Plan",
+			BillingCycles:      tt.cycles,
+			PaymentPreferences: &PaymentPreferences{AutoBillOutstanding: true, PaymentFailureThreshold: 3},
+		}
+		if err := plan.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: got %v, want valid %t", tt.name, err, tt.valid)
+		}
+	}
+
+	for name, plan := range map[string]SubscriptionPlan{
+		"no product":             {Name: "Plan", BillingCycles: []BillingCycle{regular}, PaymentPreferences: &PaymentPreferences{}},
+		"no name":                {ProductID: "PROD-XXCD1234QWER65782", BillingCycles: []BillingCycle{regular}, PaymentPreferences: &PaymentPreferences{}},
+		"no payment preferences": {ProductID: "PROD-XXCD1234QWER65782", Name: "Plan", BillingCycles: []BillingCycle{regular}},
+	} {
+		if err := plan.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestPricingSchemeValidate(t *testing.T) {
+	tier := func(start, end, value string) PricingTier {
+		return PricingTier{StartingQuantity: start, EndingQuantity: end, Amount: Money{Currency: "USD", Value: value}}
+	}
+
+	tests := []struct {
+		name    string
+		scheme  PricingScheme
+		valid   bool
+		wantErr error
+	}{
+		{"fixed price", *fixedPrice("10.00"), true, nil},
+		{"tiered", PricingScheme{PricingModel: PricingModelTiered, Tiers: []PricingTier{tier("1", "10", "10.00"), tier("11", "", "8.00")}}, true, nil},
+		{"volume", PricingScheme{PricingModel: PricingModelVolume, Tiers: []PricingTier{tier("1", "", "10.00")}}, true, nil},
+		{"nothing", PricingScheme{}, false, nil},
+		{"fixed price with tiers", PricingScheme{FixedPrice: &Money{Currency: "USD", Value: "10.00"}, Tiers: []PricingTier{tier("1", "", "10.00")}}, false, nil},
+		{"tiered with fixed price", PricingScheme{PricingModel: PricingModelTiered, FixedPrice: &Money{Currency: "USD", Value: "10.00"}, Tiers: []PricingTier{tier("1", "", "10.00")}}, false, nil},
+		{"tiered without tiers", PricingScheme{PricingModel: PricingModelTiered}, false, nil},
+		{"unknown model", PricingScheme{PricingModel: "GRADUATED", Tiers: []PricingTier{tier("1", "", "10.00")}}, false, nil},
+		{"open tier before the last", PricingScheme{PricingModel: PricingModelTiered, Tiers: []PricingTier{tier("1", "", "10.00"), tier("11", "", "8.00")}}, false, nil},
+		{"tier precision", PricingScheme{PricingModel: PricingModelTiered, Tiers: []PricingTier{tier("1", "", "10.001")}}, false, nil},
+		{
+			name:    "tier currencies",
+			scheme:  PricingScheme{PricingModel: PricingModelVolume, Tiers: []PricingTier{tier("1", "10", "10.00"), {StartingQuantity: "11", Amount: Money{Currency: "EUR", Value: "8.00"}}}},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		err := tt.scheme.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: got %v, want valid %t", tt.name, err, tt.valid)
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package paypal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionActions(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id":"I-BW452GLLEP1G","status":"ACTIVE"}`)
+	c := rec.client(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		path string
+		body string
+	}{
+		{
+			name: "suspend",
+			call: func() error { return c.SuspendSubscription(ctx, "I-BW452GLLEP1G", "Item out of stock") },
+			path: "/v1/billing/subscriptions/I-BW452GLLEP1G/suspend",
+			body: `{"reason":"Item out of stock"}`,
+		},
+		{
+			name: "activate",
+			call: func() error { return c.ActivateSubscription(ctx, "I-BW452GLLEP1G", "Reactivating on customer request") },
+			path: "/v1/billing/subscriptions/I-BW452GLLEP1G/activate",
+			body: `{"reason":"Reactivating on customer request"}`,
+		},
+		{
+			name: "cancel",
+			call: func() error { return c.CancelSubscription(ctx, "I-BW452GLLEP1G", "Not satisfied with the service") },
+			path: "/v1/billing/subscriptions/I-BW452GLLEP1G/cancel",
+			body: `{"reason":"Not satisfied with the service"}`,
+		},
+		{
+			name: "revise",
+			call: func() error {
+				_, err := c.ReviseSubscription(ctx, "I-BW452GLLEP1G", ReviseSubscriptionRequest{PlanID: "P-5ML4271244454362WXNWU5NQ", Quantity: "2"})
+				return err
+			},
+			path: "/v1/billing/subscriptions/I-BW452GLLEP1G/revise",
+			body: `{"plan_id":"P-5ML4271244454362WXNWU5NQ","quantity":"2"}`,
+		},
+		{
+			name: "create",
+			call: func() error {
+				_, err := c.CreateSubscriptionWithPaypalRequestID(ctx, SubscriptionBase{PlanID: "P-5ML4271244454362WXNWU5NQ", CustomID: "customer-42"}, "create-customer-42")
+				return err
+			},
+			path: "/v1/billing/subscriptions",
+			body: `{"plan_id":"P-5ML4271244454362WXNWU5NQ","custom_id":"customer-42"}`,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		req := rec.last(t)
+		if req.Method != "POST" || req.Path != tt.path {
+			t.Errorf("%s: sent %s %s", tt.name, req.Method, req.Path)
+		}
+		if !jsonEqual(t, req.Body, tt.body) {
+			t.Errorf("%s: body %s, want %s", tt.name, req.Body, tt.body)
+		}
+	}
+	if got := rec.last(t).Header.Get("PayPal-Request-Id"); got != "create-customer-42" {
+		t.Errorf("create: PayPal-Request-Id %q", got)
+	}
+}
+
+func TestCaptureSubscriptionPayment(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id":"TRANS-1","status":"COMPLETED","amount_with_breakdown":{"gross_amount":{"currency_code":"USD","value":"10.00"}}}`)
+	c := rec.client(t, nil)
+
+	transaction, err := c.CaptureSubscriptionPaymentWithPaypalRequestID(context.Background(), "I-BW452GLLEP1G", CaptureSubscriptionPaymentRequest{
+		Note:   "Charging as the balance reached the limit",
+		Amount: Money{Currency: "USD", Value: "10.00"},
+	}, "capture-I-BW452GLLEP1G-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "POST" || req.Path != "/v1/billing/subscriptions/I-BW452GLLEP1G/capture" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Header.Get("PayPal-Request-Id"); got != "capture-I-BW452GLLEP1G-1" {
+		t.Errorf("PayPal-Request-Id %q", got)
+	}
+	if want := `{"note":"Charging as the balance reached the limit","capture_type":"OUTSTANDING_BALANCE","amount":{"currency_code":"USD","value":"10.00"}}`; !jsonEqual(t, req.Body, want) {
+		t.Errorf("body %s, want %s", req.Body, want)
+	}
+	if transaction.ID != "TRANS-1" || transaction.AmountWithBreakdown.GrossAmount.Value != "10.00" {
+		t.Errorf("transaction %+v", transaction)
+	}
+}
+
+func TestListSubscriptionTransactions(t *testing.T) {
+	rec := newAPIRecorder(t, `{"transactions":[{"id":"TRANS-1","status":"COMPLETED","time":"2021-07-08T00:00:05Z"}],"total_items":1,"total_pages":1}`)
+	c := rec.client(t, nil)
+
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.FixedZone("", 2*60*60))
+	response, err := c.ListSubscriptionTransactions(context.Background(), "I-BW452GLLEP1G", start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "GET" || req.Path != "/v1/billing/subscriptions/I-BW452GLLEP1G/transactions" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Query.Encode(); got != "end_time=2021-07-31T22%3A00%3A00Z&start_time=2021-06-30T22%3A00%3A00Z" {
+		t.Errorf("query %s", got)
+	}
+	if len(response.Transactions) != 1 || response.Transactions[0].ID != "TRANS-1" {
+		t.Errorf("response %+v", response)
+	}
+}
@@ -9,6 +9,7 @@
 package paypal
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"sync"
@@ -31,6 +32,270 @@ const (
 	EventPaymentCaptureRefunded        string = "PAYMENT.CAPTURE.REFUNDED"
 	EventMerchantOnboardingCompleted   string = "MERCHANT.ONBOARDING.COMPLETED"
 	EventMerchantPartnerConsentRevoked string = "MERCHANT.PARTNER-CONSENT.REVOKED"
+	EventCheckoutOrderCompleted        string = "CHECKOUT.ORDER.COMPLETED"
+	EventPaymentCapturePending         string = "PAYMENT.CAPTURE.PENDING"
+	EventPaymentCaptureReversed        string = "PAYMENT.CAPTURE.REVERSED"
+	EventCustomerDisputeCreated        string = "CUSTOMER.DISPUTE.CREATED"
+	EventCustomerDisputeUpdated        string = "CUSTOMER.DISPUTE.UPDATED"
+	EventCustomerDisputeResolved       string = "CUSTOMER.DISPUTE.RESOLVED"
+)
+
+const (
+	AuthorizationStatusCreated           string = "CREATED"
+	AuthorizationStatusCaptured          string = "CAPTURED"
+	AuthorizationStatusDenied            string = "DENIED"
+	AuthorizationStatusExpired           string = "EXPIRED"
+	AuthorizationStatusPartiallyCaptured string = "PARTIALLY_CAPTURED"
+	AuthorizationStatusVoided            string = "VOIDED"
+	AuthorizationStatusPending           string = "PENDING"
+)
+
+const (
+	CaptureStatusCompleted         string = "COMPLETED"
+	CaptureStatusDeclined          string = "DECLINED"
+	CaptureStatusPartiallyRefunded string = "PARTIALLY_REFUNDED"
+	CaptureStatusPending           string = "PENDING"
+	CaptureStatusRefunded          string = "REFUNDED"
+	CaptureStatusFailed            string = "FAILED"
+)
+
+const (
+	RefundStatusCancelled string = "CANCELLED"
+	RefundStatusFailed    string = "FAILED"
+	RefundStatusPending   string = "PENDING"
+	RefundStatusCompleted string = "COMPLETED"
+)
+
+// Possible values for `type` in Product
+const (
+	ProductTypePhysical string = "PHYSICAL"
+	ProductTypeDigital  string = "DIGITAL"
+	ProductTypeService  string = "SERVICE"
+)
+
+// A few values for `category` in Product, PayPal accepts many more
+// Doc: https://developer.paypal.com/docs/api/catalog-products/v1/#products_create
+const (
+	ProductCategorySoftware                     string = "SOFTWARE"
+	ProductCategoryOnlineServices               string = "ONLINE_SERVICES"
+	ProductCategoryDigitalMediaBooksMoviesMusic string = "DIGITAL_MEDIA_BOOKS_MOVIES_MUSIC"
+	ProductCategoryEducationalAndTextbooks      string = "EDUCATIONAL_AND_TEXTBOOKS"
+	ProductCategoryMembershipClubs              string = "MEMBERSHIP_CLUBS_AND_ORGANIZATIONS"
+	ProductCategoryOnlineGaming                 string = "ONLINE_GAMING"
+)
+
+// Possible values for `status` in SubscriptionPlan
+const (
+	SubscriptionPlanStatusCreated  string = "CREATED"
+	SubscriptionPlanStatusInactive string = "INACTIVE"
+	SubscriptionPlanStatusActive   string = "ACTIVE"
+)
+
+// Possible values for `tenure_type` in BillingCycle
+const (
+	TenureTypeRegular string = "REGULAR"
+	TenureTypeTrial   string = "TRIAL"
+)
+
+// Possible values for `interval_unit` in Frequency
+const (
+	IntervalUnitDay   string = "DAY"
+	IntervalUnitWeek  string = "WEEK"
+	IntervalUnitMonth string = "MONTH"
+	IntervalUnitYear  string = "YEAR"
+)
+
+// Possible values for `pricing_model` in PricingScheme, empty means FixedPrice
+const (
+	PricingModelVolume string = "VOLUME"
+	PricingModelTiered string = "TIERED"
+)
+
+// Possible values for `setup_fee_failure_action` in PaymentPreferences
+const (
+	SetupFeeFailureActionContinue string = "CONTINUE"
+	SetupFeeFailureActionCancel   string = "CANCEL"
+)
+
+// Possible values for `status` in Subscription
+const (
+	SubscriptionStatusApprovalPending string = "APPROVAL_PENDING"
+	SubscriptionStatusApproved        string = "APPROVED"
+	SubscriptionStatusActive          string = "ACTIVE"
+	SubscriptionStatusSuspended       string = "SUSPENDED"
+	SubscriptionStatusCancelled       string = "CANCELLED"
+	SubscriptionStatusExpired         string = "EXPIRED"
+)
+
+// Possible values for `capture_type` in CaptureSubscriptionPaymentRequest
+const (
+	CaptureTypeOutstandingBalance string = "OUTSTANDING_BALANCE"
+)
+
+// Possible values for `status` in Invoice
+const (
+	InvoiceStatusDraft             string = "DRAFT"
+	InvoiceStatusSent              string = "SENT"
+	InvoiceStatusScheduled         string = "SCHEDULED"
+	InvoiceStatusPaid              string = "PAID"
+	InvoiceStatusMarkedAsPaid      string = "MARKED_AS_PAID"
+	InvoiceStatusCancelled         string = "CANCELLED"
+	InvoiceStatusRefunded          string = "REFUNDED"
+	InvoiceStatusPartiallyPaid     string = "PARTIALLY_PAID"
+	InvoiceStatusPartiallyRefunded string = "PARTIALLY_REFUNDED"
+	InvoiceStatusMarkedAsRefunded  string = "MARKED_AS_REFUNDED"
+	InvoiceStatusUnpaid            string = "UNPAID"
+	InvoiceStatusPaymentPending    string = "PAYMENT_PENDING"
+)
+
+// Possible values for `term_type` in InvoicePaymentTerm
+const (
+	InvoiceTermDueOnReceipt       string = "DUE_ON_RECEIPT"
+	InvoiceTermDueOnDateSpecified string = "DUE_ON_DATE_SPECIFIED"
+	InvoiceTermNet10              string = "NET_10"
+	InvoiceTermNet15              string = "NET_15"
+	InvoiceTermNet30              string = "NET_30"
+	InvoiceTermNet45              string = "NET_45"
+	InvoiceTermNet60              string = "NET_60"
+	InvoiceTermNet90              string = "NET_90"
+	InvoiceTermNoDueDate          string = "NO_DUE_DATE"
+)
+
+// Possible values for `method` in InvoicePaymentDetail and InvoiceRefundDetail
+const (
+	InvoicePaymentMethodBankTransfer string = "BANK_TRANSFER"
+	InvoicePaymentMethodCash         string = "CASH"
+	InvoicePaymentMethodCheck        string = "CHECK"
+	InvoicePaymentMethodCreditCard   string = "CREDIT_CARD"
+	InvoicePaymentMethodDebitCard    string = "DEBIT_CARD"
+	InvoicePaymentMethodPayPal       string = "PAYPAL"
+	InvoicePaymentMethodWireTransfer string = "WIRE_TRANSFER"
+	InvoicePaymentMethodOther        string = "OTHER"
+)
+
+// Possible values for `action` in InvoiceQRCodeRequest
+const (
+	InvoiceQRCodeActionPay     string = "pay"
+	InvoiceQRCodeActionDetails string = "details"
+)
+
+// Possible values for `dispute_life_cycle_stage` in Dispute, in the order a dispute moves through them
+const (
+	DisputeStageInquiry        string = "INQUIRY"
+	DisputeStageChargeback     string = "CHARGEBACK"
+	DisputeStagePreArbitration string = "PRE_ARBITRATION"
+	DisputeStageArbitration    string = "ARBITRATION"
+)
+
+// Possible values for `status` in Dispute
+const (
+	DisputeStatusOpen                     string = "OPEN"
+	DisputeStatusWaitingForBuyerResponse  string = "WAITING_FOR_BUYER_RESPONSE"
+	DisputeStatusWaitingForSellerResponse string = "WAITING_FOR_SELLER_RESPONSE"
+	DisputeStatusUnderReview              string = "UNDER_REVIEW"
+	DisputeStatusResolved                 string = "RESOLVED"
+	DisputeStatusOther                    string = "OTHER"
+)
+
+// Possible values for `dispute_state` in DisputeListParams
+const (
+	DisputeStateRequiredAction           string = "REQUIRED_ACTION"
+	DisputeStateRequiredOtherPartyAction string = "REQUIRED_OTHER_PARTY_ACTION"
+	DisputeStateUnderPayPalReview        string = "UNDER_PAYPAL_REVIEW"
+	DisputeStateResolved                 string = "RESOLVED"
+	DisputeStateOpenInquiries            string = "OPEN_INQUIRIES"
+	DisputeStateAppealable               string = "APPEALABLE"
+)
+
+// Possible values for `reason` in Dispute
+const (
+	DisputeReasonMerchandiseNotReceived   string = "MERCHANDISE_OR_SERVICE_NOT_RECEIVED"
+	DisputeReasonNotAsDescribed           string = "MERCHANDISE_OR_SERVICE_NOT_AS_DESCRIBED"
+	DisputeReasonUnauthorised             string = "UNAUTHORISED"
+	DisputeReasonCreditNotProcessed       string = "CREDIT_NOT_PROCESSED"
+	DisputeReasonDuplicateTransaction     string = "DUPLICATE_TRANSACTION"
+	DisputeReasonIncorrectAmount          string = "INCORRECT_AMOUNT"
+	DisputeReasonPaymentByOtherMeans      string = "PAYMENT_BY_OTHER_MEANS"
+	DisputeReasonCanceledRecurringBilling string = "CANCELED_RECURRING_BILLING"
+	DisputeReasonProblemWithRemittance    string = "PROBLEM_WITH_REMITTANCE"
+	DisputeReasonOther                    string = "OTHER"
+)
+
+// A few values for `evidence_type` in DisputeEvidence, PayPal accepts many more
+const (
+	EvidenceProofOfFulfillment string = "PROOF_OF_FULFILLMENT"
+	EvidenceProofOfRefund      string = "PROOF_OF_REFUND"
+	EvidenceProofOfDelivery    string = "PROOF_OF_DELIVERY_SIGNATURE"
+	EvidenceProofOfReceiptCopy string = "PROOF_OF_RECEIPT_COPY"
+	EvidenceReturnPolicy       string = "RETURN_POLICY"
+	EvidenceBillingAgreement   string = "BILLING_AGREEMENT"
+	EvidenceProofOfReshipment  string = "PROOF_OF_RESHIPMENT"
+	EvidenceItemDescription    string = "ITEM_DESCRIPTION"
+	EvidenceProofOfReturn      string = "PROOF_OF_RETURN"
+	EvidenceOther              string = "OTHER"
+)
+
+// Possible values for `offer_type` in MakeOfferRequest
+const (
+	DisputeOfferRefund                   string = "REFUND"
+	DisputeOfferRefundWithReturn         string = "REFUND_WITH_RETURN"
+	DisputeOfferRefundWithReplacement    string = "REFUND_WITH_REPLACEMENT"
+	DisputeOfferReplacementWithoutRefund string = "REPLACEMENT_WITHOUT_REFUND"
+)
+
+// Possible values for `status` in SetupToken
+const (
+	SetupTokenStatusCreated             string = "CREATED"
+	SetupTokenStatusPayerActionRequired string = "PAYER_ACTION_REQUIRED"
+	SetupTokenStatusApproved            string = "APPROVED"
+	SetupTokenStatusVaulted             string = "VAULTED"
+	SetupTokenStatusTokenized           string = "TOKENIZED"
+)
+
+// Possible values for `type` in PaymentSourceToken
+const (
+	PaymentSourceTokenSetupToken       string = "SETUP_TOKEN"
+	PaymentSourceTokenBillingAgreement string = "BILLING_AGREEMENT"
+)
+
+// Possible values for `usage_type` in VaultPayPal
+const (
+	VaultUsageMerchant string = "MERCHANT"
+	VaultUsagePlatform string = "PLATFORM"
+)
+
+// Possible values for `recipient_type` in PayoutItem
+const (
+	PayoutRecipientEmail    string = "EMAIL"
+	PayoutRecipientPhone    string = "PHONE"
+	PayoutRecipientPayPalID string = "PAYPAL_ID"
+)
+
+// Possible values for `batch_status` in BatchHeader
+const (
+	PayoutBatchStatusDenied     string = "DENIED"
+	PayoutBatchStatusPending    string = "PENDING"
+	PayoutBatchStatusProcessing string = "PROCESSING"
+	PayoutBatchStatusSuccess    string = "SUCCESS"
+	PayoutBatchStatusCanceled   string = "CANCELED"
+)
+
+// Possible values for `transaction_status` in PayoutItemResponse
+const (
+	PayoutItemStatusSuccess   string = "SUCCESS"
+	PayoutItemStatusFailed    string = "FAILED"
+	PayoutItemStatusPending   string = "PENDING"
+	PayoutItemStatusUnclaimed string = "UNCLAIMED"
+	PayoutItemStatusReturned  string = "RETURNED"
+	PayoutItemStatusOnHold    string = "ONHOLD"
+	PayoutItemStatusBlocked   string = "BLOCKED"
+	PayoutItemStatusRefunded  string = "REFUNDED"
+	PayoutItemStatusReversed  string = "REVERSED"
+)
+
+const (
+	VerificationStatusSuccess string = "SUCCESS"
+	VerificationStatusFailure string = "FAILURE"
 )
 
 const (
@@ -38,6 +303,21 @@ const (
 	OrderIntentAuthorize string = "AUTHORIZE"
 )
 
+const (
+	OrderStatusCreated             string = "CREATED"
+	OrderStatusSaved               string = "SAVED"
+	OrderStatusApproved            string = "APPROVED"
+	OrderStatusVoided              string = "VOIDED"
+	OrderStatusCompleted           string = "COMPLETED"
+	OrderStatusPayerActionRequired string = "PAYER_ACTION_REQUIRED"
+)
+
+const (
+	PatchOperationAdd     string = "add"
+	PatchOperationReplace string = "replace"
+	PatchOperationRemove  string = "remove"
+)
+
 
 // Amount struct
 type (
@@ -57,9 +337,11 @@ type (
 		Intent string `json:"intent"`
 		PurchaseUnits []PurchaseUnit `json:"purchase_units"`
 		ApplicationContext  ApplicationContext `json:"application_context"`
-		Resource *CallBackPayoutItem
+		Resource *CallBackPayoutItem `json:"-"` // Not part of an order, never sent
 	}
 
+	// JSONTime is a time.Time decoding every timestamp format PayPal emits,
+	// such as the reporting API's 2021-07-07T17:53:00+0000
 	JSONTime time.Time
 
 	Amount struct {
@@ -77,11 +359,13 @@ type (
 	// ApplicationContext struct
 	//Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#definition-application_context
 	ApplicationContext struct {
-		BrandName          string             `json:"brand_name,omitempty"`
-		Locale             string             `json:"locale,omitempty"`
+		BrandName string `json:"brand_name,omitempty"`
+		Locale    string `json:"locale,omitempty"`
 		//LandingPage        string `json:"landing_page,omitempty"` // not found in documentation
-		ReturnURL string `json:"return_url,omitempty"`
-		CancelURL string `json:"cancel_url,omitempty"`
+		ReturnURL          string `json:"return_url,omitempty"`
+		CancelURL          string `json:"cancel_url,omitempty"`
+		ShippingPreference string `json:"shipping_preference,omitempty"`
+		UserAction         string `json:"user_action,omitempty"`
 	}
 
 	// Authorization struct
@@ -90,10 +374,12 @@ type (
 		CustomID         string                `json:"custom_id,omitempty"`
 		InvoiceID        string                `json:"invoice_id,omitempty"`
 		Status           string                `json:"status,omitempty"`
+		StatusDetails    *CaptureStatusDetails `json:"status_details,omitempty"`
 		Amount           *PurchaseUnitAmount   `json:"amount,omitempty"`
-		CreateTime       *time.Time            `json:"create_time,omitempty"`
-		UpdateTime       *time.Time            `json:"update_time,omitempty"`
-		ExpirationTime   *time.Time            `json:"expiration_time,omitempty"`
+		SellerProtection *SellerProtection     `json:"seller_protection,omitempty"`
+		CreateTime       *JSONTime             `json:"create_time,omitempty"`
+		UpdateTime       *JSONTime             `json:"update_time,omitempty"`
+		ExpirationTime   *JSONTime             `json:"expiration_time,omitempty"`
 		Links            []Link                `json:"links,omitempty"`
 	}
 
@@ -106,36 +392,90 @@ type (
 	//https://developer.paypal.com/docs/api/payments/v2/#captures_get
 	CaptureDetailsResponse struct {
 		Status                    string                     `json:"status,omitempty"`
+		StatusDetails             *CaptureStatusDetails      `json:"status_details,omitempty"`
 		ID                        string                     `json:"id,omitempty"`
 		Amount                    *Money                     `json:"amount,omitempty"`
 		InvoiceID                 string                     `json:"invoice_id,omitempty"`
 		CustomID                  string                     `json:"custom_id,omitempty"`
+		SellerProtection          *SellerProtection          `json:"seller_protection,omitempty"`
 		FinalCapture              bool                       `json:"final_capture,omitempty"`
+		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
 		DisbursementMode          string                     `json:"disbursement_mode,omitempty"`
 		Links                     []Link                     `json:"links,omitempty"`
-		UpdateTime                *time.Time                 `json:"update_time,omitempty"`
-		CreateTime                *time.Time                 `json:"create_time,omitempty"`
+		UpdateTime                *JSONTime                  `json:"update_time,omitempty"`
+		CreateTime                *JSONTime                  `json:"create_time,omitempty"`
+	}
+	// Refund struct
+	//Doc: https://developer.paypal.com/docs/api/payments/v2/#refunds_get
+	Refund struct {
+		ID                      string                  `json:"id,omitempty"`
+		Status                  string                  `json:"status,omitempty"`
+		StatusDetails           *CaptureStatusDetails   `json:"status_details,omitempty"`
+		Amount                  *Money                  `json:"amount,omitempty"`
+		InvoiceID               string                  `json:"invoice_id,omitempty"`
+		CustomID                string                  `json:"custom_id,omitempty"`
+		AcquirerReferenceNumber string                  `json:"acquirer_reference_number,omitempty"`
+		NoteToPayer             string                  `json:"note_to_payer,omitempty"`
+		SellerPayableBreakdown  *SellerPayableBreakdown `json:"seller_payable_breakdown,omitempty"`
+		Links                   []Link                  `json:"links,omitempty"`
+		CreateTime              *JSONTime               `json:"create_time,omitempty"`
+		UpdateTime              *JSONTime               `json:"update_time,omitempty"`
+	}
+
+	// PaymentCaptureRequest - https://developer.paypal.com/docs/api/payments/v2/#authorizations_capture
+	PaymentCaptureRequest struct {
+		Amount         *Money `json:"amount,omitempty"`
+		InvoiceID      string `json:"invoice_id,omitempty"`
+		FinalCapture   bool   `json:"final_capture,omitempty"`
+		NoteToPayer    string `json:"note_to_payer,omitempty"`
+		SoftDescriptor string `json:"soft_descriptor,omitempty"`
+	}
+
+	// RefundCaptureRequest - https://developer.paypal.com/docs/api/payments/v2/#captures_refund
+	RefundCaptureRequest struct {
+		Amount      *Money `json:"amount,omitempty"`
+		InvoiceID   string `json:"invoice_id,omitempty"`
+		CustomID    string `json:"custom_id,omitempty"`
+		NoteToPayer string `json:"note_to_payer,omitempty"`
+	}
+
+	// SellerPayableBreakdown has the amounts a refund takes back from the seller
+	//Doc: https://developer.paypal.com/docs/api/payments/v2/#definition-seller_payable_breakdown
+	SellerPayableBreakdown struct {
+		GrossAmount         *Money        `json:"gross_amount,omitempty"`
+		PaypalFee           *Money        `json:"paypal_fee,omitempty"`
+		PlatformFees        []PlatformFee `json:"platform_fees,omitempty"`
+		NetAmount           *Money        `json:"net_amount,omitempty"`
+		TotalRefundedAmount *Money        `json:"total_refunded_amount,omitempty"`
 	}
+
 	PaymentSource struct {
-		Card  *PaymentSourceCard  `json:"card"`
-		Token *PaymentSourceToken `json:"token"`
+		Card   *PaymentSourceCard   `json:"card,omitempty"`
+		Token  *PaymentSourceToken  `json:"token,omitempty"`
+		PayPal *PaymentSourcePayPal `json:"paypal,omitempty"`
 	}
 	PaymentSourceCard struct {
-		ID             string              `json:"id"`
-		Name           string              `json:"name"`
-		Number         string              `json:"number"`
-		Expiry         string              `json:"expiry"`
-		SecurityCode   string              `json:"security_code"`
-		LastDigits     string              `json:"last_digits"`
-		CardType       string              `json:"card_type"`
+		ID           string `json:"id,omitempty"`
+		Name         string `json:"name,omitempty"`
+		Number       string `json:"number,omitempty"`
+		Expiry       string `json:"expiry,omitempty"`
+		SecurityCode string `json:"security_code,omitempty"`
+		LastDigits   string `json:"last_digits,omitempty"`
+		CardType     string `json:"card_type,omitempty"`
+		VaultID      string `json:"vault_id,omitempty"` // A vault payment token, instead of the card data
 	}
 	PaymentSourceToken struct {
 		ID   string `json:"id"`
 		Type string `json:"type"`
 	}
+	// PaymentSourcePayPal pays with a PayPal wallet saved in the vault
+	PaymentSourcePayPal struct {
+		VaultID      string `json:"vault_id,omitempty"`
+		EmailAddress string `json:"email_address,omitempty"`
+	}
 	// CaptureOrderRequest - https://developer.paypal.com/docs/api/orders/v2/#orders_capture
 	CaptureOrderRequest struct {
-		PaymentSource *PaymentSource `json:"payment_source"`
+		PaymentSource *PaymentSource `json:"payment_source,omitempty"`
 	}
 
 	// SenderBatchHeader struct
@@ -143,6 +483,55 @@ type (
 		EmailSubject  string `json:"email_subject"`
 		EmailMessage  string `json:"email_message"`
 		SenderBatchID string `json:"sender_batch_id,omitempty"`
+		RecipientType string `json:"recipient_type,omitempty"`
+	}
+
+	// Payout is the request of CreatePayout
+	//Doc: https://developer.paypal.com/docs/api/payments.payouts-batch/v1/#payouts_post
+	Payout struct {
+		SenderBatchHeader *SenderBatchHeader `json:"sender_batch_header"`
+		Items             []PayoutItem       `json:"items"`
+	}
+
+	// PayoutItem pays one receiver, an email, phone number or PayPal ID by RecipientType
+	PayoutItem struct {
+		RecipientType   string        `json:"recipient_type"`
+		RecipientWallet string        `json:"recipient_wallet,omitempty"`
+		Receiver        string        `json:"receiver"`
+		Amount          *AmountPayout `json:"amount"`
+		Note            string        `json:"note,omitempty"`
+		SenderItemID    string        `json:"sender_item_id,omitempty"`
+	}
+
+	// PayoutResponse is a payout batch, Items is one page of its items
+	PayoutResponse struct {
+		BatchHeader *BatchHeader         `json:"batch_header"`
+		Items       []PayoutItemResponse `json:"items"`
+		TotalItems  int                  `json:"total_items,omitempty"`
+		TotalPages  int                  `json:"total_pages,omitempty"`
+		Links       []Link               `json:"links"`
+	}
+
+	// PayoutItemResponse is the state of one payout item
+	PayoutItemResponse struct {
+		PayoutItemID      string         `json:"payout_item_id"`
+		TransactionID     string         `json:"transaction_id,omitempty"`
+		ActivityID        string         `json:"activity_id,omitempty"`
+		TransactionStatus string         `json:"transaction_status"`
+		PayoutItemFee     *AmountPayout  `json:"payout_item_fee,omitempty"`
+		PayoutBatchID     string         `json:"payout_batch_id"`
+		SenderBatchID     string         `json:"sender_batch_id,omitempty"`
+		PayoutItem        *PayoutItem    `json:"payout_item"`
+		TimeProcessed     *JSONTime      `json:"time_processed,omitempty"`
+		Errors            *ErrorResponse `json:"errors,omitempty"`
+		Links             []Link         `json:"links"`
+	}
+
+	// PayoutBatchParams pages the items of GetPayoutBatch, zero values are not sent
+	PayoutBatchParams struct {
+		Page          int  // Starts at 1
+		PageSize      int  // At most 1000
+		TotalRequired bool // Fills TotalItems and TotalPages of the response
 	}
 	// BatchHeader struct
 	BatchHeader struct {
@@ -150,35 +539,44 @@ type (
 		Fees              *AmountPayout      `json:"fees,omitempty"`
 		PayoutBatchID     string             `json:"payout_batch_id,omitempty"`
 		BatchStatus       string             `json:"batch_status,omitempty"`
-		TimeCreated       *time.Time         `json:"time_created,omitempty"`
-		TimeCompleted     *time.Time         `json:"time_completed,omitempty"`
+		TimeCreated       *JSONTime          `json:"time_created,omitempty"`
+		TimeCompleted     *JSONTime          `json:"time_completed,omitempty"`
 		SenderBatchHeader *SenderBatchHeader `json:"sender_batch_header,omitempty"`
 	}
 
 	// Capture struct
 	Capture struct {
-		ID             string     `json:"id,omitempty"`
-		Amount         *Amount    `json:"amount,omitempty"`
-		State          string     `json:"state,omitempty"`
-		ParentPayment  string     `json:"parent_payment,omitempty"`
-		TransactionFee string     `json:"transaction_fee,omitempty"`
-		IsFinalCapture bool       `json:"is_final_capture"`
-		CreateTime     *time.Time `json:"create_time,omitempty"`
-		UpdateTime     *time.Time `json:"update_time,omitempty"`
-		Links          []Link     `json:"links,omitempty"`
+		ID             string    `json:"id,omitempty"`
+		Amount         *Amount   `json:"amount,omitempty"`
+		State          string    `json:"state,omitempty"`
+		ParentPayment  string    `json:"parent_payment,omitempty"`
+		TransactionFee string    `json:"transaction_fee,omitempty"`
+		IsFinalCapture bool      `json:"is_final_capture"`
+		CreateTime     *JSONTime `json:"create_time,omitempty"`
+		UpdateTime     *JSONTime `json:"update_time,omitempty"`
+		Links          []Link    `json:"links,omitempty"`
 	}
 
 	// Client represents a Paypal REST API Client
 	Client struct {
-		sync.Mutex
+		sync.RWMutex
 		Client               *http.Client
 		ClientID             string
 		Secret               string
 		Domain              string
-		Log                  io.Writer // If user set log file name all requests will be logged there
+		Log                  io.Writer // If user set log file name all requests will be logged there, see NewWriterLogger
+		Logger               Logger    // Structured request log, takes precedence over Log
+		Redactor             *Redactor // Fields hidden in logs, DefaultRedactionRules when nil
 		Token                *TokenResponse
+		CertFetcher          CertFetcher  // Used to verify webhook signatures, DefaultCertFetcher when nil
+		TokenStore           TokenStore   // Shares access tokens between clients, nil keeps them in this Client only
+		Retry                *RetryPolicy // Retries transient failures, nil makes a single attempt
 		tokenExpiresAt       time.Time
+		tokenCall            *tokenCall
+		middlewares          []Middleware
 		returnRepresentation bool
+		acceptLanguage       string
+		partnerAttributionID string
 	}
 
 	// Currency struct
@@ -189,8 +587,8 @@ type (
 
 	// LastPayment struct
 	LastPayment struct {
-		Amount Money     `json:"amount,omitempty"`
-		Time   time.Time `json:"time,omitempty"`
+		Amount Money    `json:"amount,omitempty"`
+		Time   JSONTime `json:"time,omitempty"`
 	}
 
 	// Details structure used in Amount structures as optional value
@@ -287,6 +685,13 @@ type (
 	PurchaseUnit struct {
 		ReferenceID        string              `json:"reference_id"`
 		Amount             *PurchaseUnitAmount `json:"amount,omitempty"`
+		Payee              *PayeeForOrders     `json:"payee,omitempty"`
+		Description        string              `json:"description,omitempty"`
+		CustomID           string              `json:"custom_id,omitempty"`
+		InvoiceID          string              `json:"invoice_id,omitempty"`
+		SoftDescriptor     string              `json:"soft_descriptor,omitempty"`
+		Items              []Item              `json:"items,omitempty"`
+		Shipping           *ShippingDetail     `json:"shipping,omitempty"`
 		Payments           *CapturedPayments   `json:"payments,omitempty"`
 	}
 	// Order struct
@@ -297,20 +702,65 @@ type (
 		Payer         *PayerWithNameAndPhone `json:"payer,omitempty"`
 		PurchaseUnits []PurchaseUnit         `json:"purchase_units,omitempty"`
 		Links         []Link                 `json:"links,omitempty"`
-		CreateTime    *time.Time             `json:"create_time,omitempty"`
-		UpdateTime    *time.Time             `json:"update_time,omitempty"`
+		CreateTime    *JSONTime              `json:"create_time,omitempty"`
+		UpdateTime    *JSONTime              `json:"update_time,omitempty"`
 	}
 
 	// CaptureAmount struct
 	CaptureAmount struct {
 		ID                        string                     `json:"id,omitempty"`
+		Status                    string                     `json:"status,omitempty"`
+		StatusDetails             *CaptureStatusDetails      `json:"status_details,omitempty"`
 		CustomID                  string                     `json:"custom_id,omitempty"`
+		InvoiceID                 string                     `json:"invoice_id,omitempty"`
 		Amount                    *PurchaseUnitAmount        `json:"amount,omitempty"`
+		FinalCapture              bool                       `json:"final_capture,omitempty"`
+		SellerProtection          *SellerProtection          `json:"seller_protection,omitempty"`
+		SellerReceivableBreakdown *SellerReceivableBreakdown `json:"seller_receivable_breakdown,omitempty"`
+		Links                     []Link                     `json:"links,omitempty"`
+		CreateTime                *JSONTime                  `json:"create_time,omitempty"`
+		UpdateTime                *JSONTime                  `json:"update_time,omitempty"`
 	}
 
-	// CapturedPayments has the amounts for a captured order
+	// CaptureStatusDetails explains why a capture is PENDING or DENIED
+	CaptureStatusDetails struct {
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// SellerProtection struct
+	SellerProtection struct {
+		Status            string   `json:"status,omitempty"`
+		DisputeCategories []string `json:"dispute_categories,omitempty"`
+	}
+
+	// SellerReceivableBreakdown has the fees and net amount of a capture
+	//Doc: https://developer.paypal.com/docs/api/payments/v2/#definition-seller_receivable_breakdown
+	SellerReceivableBreakdown struct {
+		GrossAmount      *Money `json:"gross_amount,omitempty"`
+		PaypalFee        *Money `json:"paypal_fee,omitempty"`
+		NetAmount        *Money `json:"net_amount,omitempty"`
+		ReceivableAmount *Money `json:"receivable_amount,omitempty"`
+	}
+
+	// CapturedPayments has the captures and authorizations of an order's purchase unit
 	CapturedPayments struct {
-		Captures []CaptureAmount `json:"captures,omitempty"`
+		Authorizations []Authorization `json:"authorizations,omitempty"`
+		Captures       []CaptureAmount `json:"captures,omitempty"`
+		Refunds        []Refund        `json:"refunds,omitempty"`
+	}
+
+	// PatchOperation is a JSON Patch operation used by the PATCH endpoints
+	//Doc: https://developer.paypal.com/docs/api/orders/v2/#definition-patch
+	PatchOperation struct {
+		Operation string      `json:"op"`
+		Path      string      `json:"path,omitempty"`
+		Value     interface{} `json:"value,omitempty"`
+		From      string      `json:"from,omitempty"`
+	}
+
+	// AuthorizeOrderRequest - https://developer.paypal.com/docs/api/orders/v2/#orders_authorize
+	AuthorizeOrderRequest struct {
+		PaymentSource *PaymentSource `json:"payment_source,omitempty"`
 	}
 
 	// CapturedPurchaseItem are items for a captured order
@@ -329,21 +779,21 @@ type (
 
 	// Sale struct
 	Sale struct {
-		ID                        string     `json:"id,omitempty"`
-		Amount                    *Amount    `json:"amount,omitempty"`
-		TransactionFee            *Currency  `json:"transaction_fee,omitempty"`
-		Description               string     `json:"description,omitempty"`
-		CreateTime                *time.Time `json:"create_time,omitempty"`
-		State                     string     `json:"state,omitempty"`
-		ParentPayment             string     `json:"parent_payment,omitempty"`
-		UpdateTime                *time.Time `json:"update_time,omitempty"`
-		PaymentMode               string     `json:"payment_mode,omitempty"`
-		PendingReason             string     `json:"pending_reason,omitempty"`
-		ReasonCode                string     `json:"reason_code,omitempty"`
-		ClearingTime              string     `json:"clearing_time,omitempty"`
-		ProtectionEligibility     string     `json:"protection_eligibility,omitempty"`
-		ProtectionEligibilityType string     `json:"protection_eligibility_type,omitempty"`
-		Links                     []Link     `json:"links,omitempty"`
+		ID                        string    `json:"id,omitempty"`
+		Amount                    *Amount   `json:"amount,omitempty"`
+		TransactionFee            *Currency `json:"transaction_fee,omitempty"`
+		Description               string    `json:"description,omitempty"`
+		CreateTime                *JSONTime `json:"create_time,omitempty"`
+		State                     string    `json:"state,omitempty"`
+		ParentPayment             string    `json:"parent_payment,omitempty"`
+		UpdateTime                *JSONTime `json:"update_time,omitempty"`
+		PaymentMode               string    `json:"payment_mode,omitempty"`
+		PendingReason             string    `json:"pending_reason,omitempty"`
+		ReasonCode                string    `json:"reason_code,omitempty"`
+		ClearingTime              string    `json:"clearing_time,omitempty"`
+		ProtectionEligibility     string    `json:"protection_eligibility,omitempty"`
+		ProtectionEligibilityType string    `json:"protection_eligibility_type,omitempty"`
+		Links                     []Link    `json:"links,omitempty"`
 	}
 
 	//ShippingAmount struct
@@ -442,20 +892,80 @@ type (
 	// The basic webhook event data type. This struct is intended to be
 	// embedded into resource type specific event structs.
 	Event struct {
-		ID              string    `json:"id"`
-		CreateTime      time.Time `json:"create_time"`
-		ResourceType    string    `json:"resource_type"`
-		EventType       string    `json:"event_type"`
-		Summary         string    `json:"summary,omitempty"`
-		Links           []Link    `json:"links"`
-		EventVersion    string    `json:"event_version,omitempty"`
-		ResourceVersion string    `json:"resource_version,omitempty"`
+		ID              string   `json:"id"`
+		CreateTime      JSONTime `json:"create_time"`
+		ResourceType    string   `json:"resource_type"`
+		EventType       string   `json:"event_type"`
+		Summary         string   `json:"summary,omitempty"`
+		Links           []Link   `json:"links"`
+		EventVersion    string   `json:"event_version,omitempty"`
+		ResourceVersion string   `json:"resource_version,omitempty"`
+	}
+
+	// WebhookEvent is an Event together with its undecoded resource, as
+	// delivered to the webhook listener
+	WebhookEvent struct {
+		Event
+		Resource json.RawMessage `json:"resource,omitempty"`
+	}
+
+	// MerchantEventResource is the resource of MERCHANT.* events
+	MerchantEventResource struct {
+		MerchantID      string `json:"merchant_id,omitempty"`
+		TrackingID      string `json:"tracking_id,omitempty"`
+		PartnerClientID string `json:"partner_client_id,omitempty"`
+		Links           []Link `json:"links,omitempty"`
+	}
+
+	// CreateWebhookRequest struct
+	CreateWebhookRequest struct {
+		URL        string             `json:"url"`
+		EventTypes []WebhookEventType `json:"event_types"`
+	}
+
+	// ListWebhookResponse struct
+	ListWebhookResponse struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+
+	// WebhookEventTypesResponse struct
+	WebhookEventTypesResponse struct {
+		EventTypes []WebhookEventType `json:"event_types"`
+	}
+
+	// ListWebhookEventsParams filters ListWebhookEvents, zero values are not sent
+	ListWebhookEventsParams struct {
+		PageSize      int
+		StartTime     time.Time
+		EndTime       time.Time
+		TransactionID string
+		EventType     string
+	}
+
+	// ListWebhookEventsResponse struct
+	ListWebhookEventsResponse struct {
+		Events []WebhookEvent `json:"events"`
+		Count  int            `json:"count,omitempty"`
+		Links  []Link         `json:"links,omitempty"`
+	}
+
+	// SimulateWebhookEventRequest struct, set either WebhookID or URL
+	SimulateWebhookEventRequest struct {
+		WebhookID       string `json:"webhook_id,omitempty"`
+		URL             string `json:"url,omitempty"`
+		EventType       string `json:"event_type"`
+		ResourceVersion string `json:"resource_version,omitempty"`
+	}
+
+	// VerifyWebhookResponse struct
+	VerifyWebhookResponse struct {
+		VerificationStatus string `json:"verification_status,omitempty"`
 	}
 
 	// WebhookEventType struct
 	WebhookEventType struct {
 		Name        string `json:"name"`
-		Description string `json:"description"`
+		Description string `json:"description,omitempty"`
 		Status      string `json:"status,omitempty"`
 	}
 	SearchPayerName struct {
@@ -559,10 +1069,863 @@ type (
 		CartInfo        *SearchCartInfo       `json:"cart_info"`
 	}
 
+	// TransactionSearchRequest filters ListTransactions, zero values are not sent
+	//Doc: https://developer.paypal.com/docs/api/transaction-search/v1/#transactions_get
+	TransactionSearchRequest struct {
+		StartDate                   time.Time
+		EndDate                     time.Time
+		TransactionID               string
+		TransactionType             string // Event code, e.g. T0006
+		TransactionStatus           string // D, P, S or V
+		TransactionCurrency         string
+		MinAmount                   string // In TransactionCurrency, e.g. "5.00"
+		MaxAmount                   string
+		PaymentInstrumentType       string // CREDITCARD or DEBITCARD
+		BalanceAffectingRecordsOnly bool
+		Fields                      []string // e.g. TransactionFieldAll, transaction_info only when empty
+		PageSize                    int
+		Page                        int
+	}
+
+	// TransactionSearchResponse is one page of a transaction search
+	TransactionSearchResponse struct {
+		TransactionDetails    []SearchTransactionDetails `json:"transaction_details"`
+		AccountNumber         string                     `json:"account_number"`
+		StartDate             JSONTime                   `json:"start_date"`
+		EndDate               JSONTime                   `json:"end_date"`
+		LastRefreshedDatetime JSONTime                   `json:"last_refreshed_datetime"`
+		Page                  int                        `json:"page"`
+		TotalItems            int                        `json:"total_items"`
+		TotalPages            int                        `json:"total_pages"`
+		Links                 []Link                     `json:"links"`
+	}
+
+	// ListBalancesParams filters ListBalances, zero values are not sent
+	//Doc: https://developer.paypal.com/docs/api/transaction-search/v1/#balances_get
+	ListBalancesParams struct {
+		AsOfTime                time.Time // Defaults to now
+		CurrencyCode            string    // Defaults to all currencies
+		IncludeCryptoCurrencies bool
+	}
+
+	// Balance of one currency, WithheldBalance is held back by PayPal e.g. for disputes
+	Balance struct {
+		Currency         string `json:"currency"`
+		Primary          bool   `json:"primary,omitempty"`
+		TotalBalance     Money  `json:"total_balance"`
+		AvailableBalance Money  `json:"available_balance"`
+		WithheldBalance  Money  `json:"withheld_balance"`
+	}
+
+	// ListBalancesResponse is returned by ListBalances
+	ListBalancesResponse struct {
+		Balances        []Balance `json:"balances"`
+		AccountID       string    `json:"account_id,omitempty"`
+		AsOfTime        JSONTime  `json:"as_of_time"`
+		LastRefreshTime JSONTime  `json:"last_refresh_time"`
+	}
+
 	SharedResponse struct {
-		CreateTime string `json:"create_time"`
-		UpdateTime string `json:"update_time"`
-		Links      []Link `json:"links"`
+		CreateTime *JSONTime `json:"create_time,omitempty"`
+		UpdateTime *JSONTime `json:"update_time,omitempty"`
+		Links      []Link    `json:"links"`
+	}
+
+	// Product is a catalog product subscription plans are offered for
+	//Doc: https://developer.paypal.com/docs/api/catalog-products/v1/
+	Product struct {
+		ID          string `json:"id,omitempty"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Category    string `json:"category,omitempty"`
+		Type        string `json:"type"`
+		ImageURL    string `json:"image_url,omitempty"`
+		HomeURL     string `json:"home_url,omitempty"`
+	}
+
+	// CreateProductResponse is a Product as returned by the catalog API
+	CreateProductResponse struct {
+		Product
+		SharedResponse
+	}
+
+	// ProductListParameters pages ListProducts
+	ProductListParameters struct {
+		ListParams
+	}
+
+	// ListProductsResponse is one page of ListProducts
+	ListProductsResponse struct {
+		Products []Product `json:"products"`
+		SharedListResponse
+	}
+
+	// SubscriptionPlan is a billing plan of a Product
+	//Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#plans
+	SubscriptionPlan struct {
+		ID                 string              `json:"id,omitempty"`
+		ProductID          string              `json:"product_id"`
+		Name               string              `json:"name"`
+		Status             string              `json:"status,omitempty"`
+		Description        string              `json:"description,omitempty"`
+		BillingCycles      []BillingCycle      `json:"billing_cycles"`
+		PaymentPreferences *PaymentPreferences `json:"payment_preferences"`
+		Taxes              *Taxes              `json:"taxes,omitempty"`
+		QuantitySupported  bool                `json:"quantity_supported,omitempty"`
+	}
+
+	// CreateSubscriptionPlanResponse is a SubscriptionPlan as returned by the API
+	CreateSubscriptionPlanResponse struct {
+		SubscriptionPlan
+		SharedResponse
+	}
+
+	// SubscriptionPlanListParameters filters ListSubscriptionPlans
+	SubscriptionPlanListParameters struct {
+		ProductID string
+		PlanIDs   string // Comma separated, at most 10
+		ListParams
+	}
+
+	// ListSubscriptionPlansResponse is one page of ListSubscriptionPlans
+	ListSubscriptionPlansResponse struct {
+		Plans []SubscriptionPlan `json:"plans"`
+		SharedListResponse
+	}
+
+	// BillingCycle is a trial or the regular period of a plan. Cycles run
+	// in Sequence order, TotalCycles 0 repeats a regular cycle forever.
+	BillingCycle struct {
+		PricingScheme *PricingScheme `json:"pricing_scheme,omitempty"` // nil for a free trial
+		Frequency     Frequency      `json:"frequency"`
+		TenureType    string         `json:"tenure_type"`
+		Sequence      int            `json:"sequence"`
+		TotalCycles   int            `json:"total_cycles"`
+	}
+
+	// Frequency of a billing cycle, e.g. every 3 MONTH
+	Frequency struct {
+		IntervalUnit  string `json:"interval_unit"`
+		IntervalCount int    `json:"interval_count,omitempty"`
+	}
+
+	// PricingScheme is either a FixedPrice, or Tiers priced by PricingModel
+	PricingScheme struct {
+		Version      int           `json:"version,omitempty"`
+		FixedPrice   *Money        `json:"fixed_price,omitempty"`
+		PricingModel string        `json:"pricing_model,omitempty"`
+		Tiers        []PricingTier `json:"tiers,omitempty"`
+		CreateTime   *JSONTime     `json:"create_time,omitempty"`
+		UpdateTime   *JSONTime     `json:"update_time,omitempty"`
+	}
+
+	// PricingTier prices quantities from StartingQuantity up to EndingQuantity,
+	// which is empty for the last tier
+	PricingTier struct {
+		StartingQuantity string `json:"starting_quantity"`
+		EndingQuantity   string `json:"ending_quantity,omitempty"`
+		Amount           Money  `json:"amount"`
+	}
+
+	// PaymentPreferences of a plan
+	PaymentPreferences struct {
+		AutoBillOutstanding     bool   `json:"auto_bill_outstanding"`
+		SetupFee                *Money `json:"setup_fee,omitempty"`
+		SetupFeeFailureAction   string `json:"setup_fee_failure_action,omitempty"`
+		PaymentFailureThreshold int    `json:"payment_failure_threshold,omitempty"`
+	}
+
+	// Taxes charged on a plan, Percentage like "10"
+	Taxes struct {
+		Percentage string `json:"percentage"`
+		Inclusive  bool   `json:"inclusive"`
+	}
+
+	// PricingSchemeUpdate replaces the pricing of one billing cycle
+	PricingSchemeUpdate struct {
+		BillingCycleSequence int           `json:"billing_cycle_sequence"`
+		PricingScheme        PricingScheme `json:"pricing_scheme"`
+	}
+
+	// UpdatePricingSchemesRequest is the body of UpdateSubscriptionPlanPricing
+	UpdatePricingSchemesRequest struct {
+		PricingSchemes []PricingSchemeUpdate `json:"pricing_schemes"`
+	}
+	// SubscriptionBase is the request of CreateSubscription
+	//Doc: https://developer.paypal.com/docs/api/subscriptions/v1/#subscriptions_create
+	SubscriptionBase struct {
+		PlanID             string              `json:"plan_id"`
+		StartTime          *JSONTime           `json:"start_time,omitempty"`
+		Quantity           string              `json:"quantity,omitempty"`
+		ShippingAmount     *Money              `json:"shipping_amount,omitempty"`
+		Subscriber         *Subscriber         `json:"subscriber,omitempty"`
+		AutoRenewal        bool                `json:"auto_renewal,omitempty"`
+		ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
+		CustomID           string              `json:"custom_id,omitempty"`
+	}
+
+	// Subscriber is the payer of a subscription
+	Subscriber struct {
+		Name            *Name           `json:"name,omitempty"`
+		EmailAddress    string          `json:"email_address,omitempty"`
+		PayerID         string          `json:"payer_id,omitempty"`
+		ShippingAddress *ShippingDetail `json:"shipping_address,omitempty"`
+	}
+
+	// Subscription as returned by the API, Links holds the "approve" link a
+	// new subscription sends its subscriber to
+	Subscription struct {
+		SubscriptionBase
+		ID               string       `json:"id"`
+		Status           string       `json:"status"`
+		StatusChangeNote string       `json:"status_change_note,omitempty"`
+		StatusUpdateTime *JSONTime    `json:"status_update_time,omitempty"`
+		BillingInfo      *BillingInfo `json:"billing_info,omitempty"`
+		SharedResponse
+	}
+
+	// BillingInfo is the billing state of a subscription
+	BillingInfo struct {
+		OutstandingBalance  Money            `json:"outstanding_balance"`
+		CycleExecutions     []CycleExecution `json:"cycle_executions,omitempty"`
+		LastPayment         *LastPayment     `json:"last_payment,omitempty"`
+		NextBillingTime     *JSONTime        `json:"next_billing_time,omitempty"`
+		FinalPaymentTime    *JSONTime        `json:"final_payment_time,omitempty"`
+		FailedPaymentsCount int              `json:"failed_payments_count"`
+	}
+
+	// CycleExecution is the progress of a subscription through one billing cycle
+	CycleExecution struct {
+		TenureType                  string `json:"tenure_type"`
+		Sequence                    int    `json:"sequence"`
+		CyclesCompleted             int    `json:"cycles_completed"`
+		CyclesRemaining             int    `json:"cycles_remaining,omitempty"`
+		CurrentPricingSchemeVersion int    `json:"current_pricing_scheme_version,omitempty"`
+		TotalCycles                 int    `json:"total_cycles,omitempty"`
+	}
+
+	// ReviseSubscriptionRequest moves a subscription to another plan or quantity
+	ReviseSubscriptionRequest struct {
+		PlanID             string              `json:"plan_id,omitempty"`
+		Quantity           string              `json:"quantity,omitempty"`
+		EffectiveTime      *JSONTime           `json:"effective_time,omitempty"`
+		ShippingAmount     *Money              `json:"shipping_amount,omitempty"`
+		ShippingAddress    *ShippingDetail     `json:"shipping_address,omitempty"`
+		ApplicationContext *ApplicationContext `json:"application_context,omitempty"`
+	}
+
+	// ReviseSubscriptionResponse holds the "approve" link the subscriber
+	// confirms a revision with
+	ReviseSubscriptionResponse struct {
+		PlanID          string          `json:"plan_id"`
+		Quantity        string          `json:"quantity,omitempty"`
+		EffectiveTime   *JSONTime       `json:"effective_time,omitempty"`
+		ShippingAmount  *Money          `json:"shipping_amount,omitempty"`
+		ShippingAddress *ShippingDetail `json:"shipping_address,omitempty"`
+		PlanOverridden  bool            `json:"plan_overridden"`
+		Links           []Link          `json:"links"`
+	}
+
+	// SubscriptionStatusChangeRequest is the body of suspend, activate and cancel
+	SubscriptionStatusChangeRequest struct {
+		Reason string `json:"reason"`
+	}
+
+	// CaptureSubscriptionPaymentRequest charges the outstanding balance of a subscription
+	CaptureSubscriptionPaymentRequest struct {
+		Note        string `json:"note"`
+		CaptureType string `json:"capture_type"`
+		Amount      Money  `json:"amount"`
+	}
+
+	// SubscriptionTransaction is a payment of a subscription
+	SubscriptionTransaction struct {
+		ID                  string                      `json:"id"`
+		Status              string                      `json:"status"`
+		AmountWithBreakdown SubscriptionAmountBreakdown `json:"amount_with_breakdown"`
+		PayerName           *Name                       `json:"payer_name,omitempty"`
+		PayerEmail          string                      `json:"payer_email,omitempty"`
+		Time                JSONTime                    `json:"time"`
+	}
+
+	// SubscriptionAmountBreakdown splits a subscription payment into fee and net amount
+	SubscriptionAmountBreakdown struct {
+		GrossAmount    Money  `json:"gross_amount"`
+		FeeAmount      *Money `json:"fee_amount,omitempty"`
+		ShippingAmount *Money `json:"shipping_amount,omitempty"`
+		TaxAmount      *Money `json:"tax_amount,omitempty"`
+		NetAmount      *Money `json:"net_amount,omitempty"`
+	}
+
+	// SubscriptionTransactionsResponse is returned by ListSubscriptionTransactions
+	SubscriptionTransactionsResponse struct {
+		Transactions []SubscriptionTransaction `json:"transactions"`
+		SharedListResponse
+	}
+
+	// Invoice of the invoicing v2 API, amounts are computed by PayPal
+	//Doc: https://developer.paypal.com/docs/api/invoicing/v2/
+	Invoice struct {
+		ID                   string                `json:"id,omitempty"`
+		ParentID             string                `json:"parent_id,omitempty"`
+		Status               string                `json:"status,omitempty"`
+		Detail               *InvoiceDetail        `json:"detail"`
+		Invoicer             *Invoicer             `json:"invoicer,omitempty"`
+		PrimaryRecipients    []InvoiceRecipient    `json:"primary_recipients,omitempty"`
+		AdditionalRecipients []InvoiceEmail        `json:"additional_recipients,omitempty"`
+		Items                []InvoiceItem         `json:"items,omitempty"`
+		Configuration        *InvoiceConfiguration `json:"configuration,omitempty"`
+		Amount               *InvoiceAmount        `json:"amount,omitempty"`
+		DueAmount            *Money                `json:"due_amount,omitempty"`
+		Gratuity             *Money                `json:"gratuity,omitempty"`
+		Payments             *InvoicePayments      `json:"payments,omitempty"`
+		Refunds              *InvoiceRefunds       `json:"refunds,omitempty"`
+		Links                []Link                `json:"links,omitempty"`
+	}
+
+	// InvoiceDetail holds the number, dates and notes of an invoice, dates are YYYY-MM-DD
+	InvoiceDetail struct {
+		CurrencyCode       string              `json:"currency_code"`
+		InvoiceNumber      string              `json:"invoice_number,omitempty"`
+		Reference          string              `json:"reference,omitempty"`
+		InvoiceDate        string              `json:"invoice_date,omitempty"`
+		Note               string              `json:"note,omitempty"`
+		TermsAndConditions string              `json:"terms_and_conditions,omitempty"`
+		Memo               string              `json:"memo,omitempty"`
+		PaymentTerm        *InvoicePaymentTerm `json:"payment_term,omitempty"`
+		Metadata           *InvoiceMetadata    `json:"metadata,omitempty"`
+	}
+
+	// InvoicePaymentTerm is when an invoice is due, DueDate only with DUE_ON_DATE_SPECIFIED
+	InvoicePaymentTerm struct {
+		TermType string `json:"term_type,omitempty"`
+		DueDate  string `json:"due_date,omitempty"`
+	}
+
+	// InvoiceMetadata is set by PayPal
+	InvoiceMetadata struct {
+		CreateTime       *JSONTime `json:"create_time,omitempty"`
+		CreatedBy        string    `json:"created_by,omitempty"`
+		LastUpdateTime   *JSONTime `json:"last_update_time,omitempty"`
+		CancelTime       *JSONTime `json:"cancel_time,omitempty"`
+		FirstSentTime    *JSONTime `json:"first_sent_time,omitempty"`
+		LastSentTime     *JSONTime `json:"last_sent_time,omitempty"`
+		RecipientViewURL string    `json:"recipient_view_url,omitempty"`
+		InvoicerViewURL  string    `json:"invoicer_view_url,omitempty"`
+	}
+
+	// Invoicer is the business sending an invoice, TaxInfo carries its tax_id
+	Invoicer struct {
+		TaxInfo
+		BusinessName    string                         `json:"business_name,omitempty"`
+		Name            *Name                          `json:"name,omitempty"`
+		Address         *ShippingDetailAddressPortable `json:"address,omitempty"`
+		EmailAddress    string                         `json:"email_address,omitempty"`
+		Phones          []InvoicePhone                 `json:"phones,omitempty"`
+		Website         string                         `json:"website,omitempty"`
+		LogoURL         string                         `json:"logo_url,omitempty"`
+		AdditionalNotes string                         `json:"additional_notes,omitempty"`
+	}
+
+	// InvoicePhone is a phone number of an invoicer or recipient
+	InvoicePhone struct {
+		CountryCode    string `json:"country_code"`
+		NationalNumber string `json:"national_number"`
+		Extension      string `json:"extension_number,omitempty"`
+		PhoneType      string `json:"phone_type,omitempty"`
+	}
+
+	// InvoiceRecipient is who an invoice is billed and shipped to
+	InvoiceRecipient struct {
+		BillingInfo  *InvoiceBillingInfo `json:"billing_info,omitempty"`
+		ShippingInfo *InvoiceContact     `json:"shipping_info,omitempty"`
+	}
+
+	// InvoiceBillingInfo is the billing contact of a recipient
+	InvoiceBillingInfo struct {
+		BusinessName   string                         `json:"business_name,omitempty"`
+		Name           *Name                          `json:"name,omitempty"`
+		Address        *ShippingDetailAddressPortable `json:"address,omitempty"`
+		EmailAddress   string                         `json:"email_address,omitempty"`
+		Phones         []InvoicePhone                 `json:"phones,omitempty"`
+		AdditionalInfo string                         `json:"additional_info,omitempty"`
+		Language       string                         `json:"language,omitempty"`
+	}
+
+	// InvoiceContact is the shipping contact of a recipient
+	InvoiceContact struct {
+		BusinessName string                         `json:"business_name,omitempty"`
+		Name         *Name                          `json:"name,omitempty"`
+		Address      *ShippingDetailAddressPortable `json:"address,omitempty"`
+	}
+
+	// InvoiceEmail is an additional recipient of an invoice
+	InvoiceEmail struct {
+		EmailAddress string `json:"email_address"`
+	}
+
+	// InvoiceItem is a line of an invoice, see InvoiceItemFromItem. It
+	// extends the order Item, except that Tax is a rate instead of an amount;
+	// Item.SKU and Item.Category aren't part of invoices and stay empty.
+	InvoiceItem struct {
+		Item
+		ID            string           `json:"id,omitempty"`
+		Tax           *InvoiceTax      `json:"tax,omitempty"`
+		ItemDate      string           `json:"item_date,omitempty"`
+		Discount      *InvoiceDiscount `json:"discount,omitempty"`
+		UnitOfMeasure string           `json:"unit_of_measure,omitempty"`
+	}
+
+	// InvoiceTax is a tax rate, PayPal computes Amount from Percent
+	InvoiceTax struct {
+		Name    string `json:"name"`
+		Percent string `json:"percent"`
+		Amount  *Money `json:"amount,omitempty"`
+	}
+
+	// InvoiceDiscount is either a Percent or an Amount
+	InvoiceDiscount struct {
+		Percent string `json:"percent,omitempty"`
+		Amount  *Money `json:"amount,omitempty"`
+	}
+
+	// InvoiceConfiguration controls tips, partial payments and tax calculation
+	InvoiceConfiguration struct {
+		PartialPayment             *InvoicePartialPayment `json:"partial_payment,omitempty"`
+		AllowTip                   bool                   `json:"allow_tip,omitempty"`
+		TaxCalculatedAfterDiscount bool                   `json:"tax_calculated_after_discount,omitempty"`
+		TaxInclusive               bool                   `json:"tax_inclusive,omitempty"`
+		TemplateID                 string                 `json:"template_id,omitempty"`
+	}
+
+	// InvoicePartialPayment lets a recipient pay part of an invoice
+	InvoicePartialPayment struct {
+		AllowPartialPayment bool   `json:"allow_partial_payment"`
+		MinimumAmountDue    *Money `json:"minimum_amount_due,omitempty"`
+	}
+
+	// InvoiceAmount is the total of an invoice
+	InvoiceAmount struct {
+		CurrencyCode string                  `json:"currency_code,omitempty"`
+		Value        string                  `json:"value,omitempty"`
+		Breakdown    *InvoiceAmountBreakdown `json:"breakdown,omitempty"`
+	}
+
+	// InvoiceAmountBreakdown is how PayPal computed an InvoiceAmount. Shipping,
+	// Custom and Discount may be set on a draft.
+	InvoiceAmountBreakdown struct {
+		ItemTotal *Money                    `json:"item_total,omitempty"`
+		Discount  *InvoiceAggregateDiscount `json:"discount,omitempty"`
+		TaxTotal  *Money                    `json:"tax_total,omitempty"`
+		Shipping  *InvoiceShippingCost      `json:"shipping,omitempty"`
+		Custom    *InvoiceCustomAmount      `json:"custom,omitempty"`
+	}
+
+	// InvoiceAggregateDiscount is the discount on the whole invoice and the sum of item discounts
+	InvoiceAggregateDiscount struct {
+		InvoiceDiscount *InvoiceDiscount `json:"invoice_discount,omitempty"`
+		ItemDiscount    *Money           `json:"item_discount,omitempty"`
+	}
+
+	// InvoiceShippingCost is the shipping fee of an invoice
+	InvoiceShippingCost struct {
+		Amount *Money      `json:"amount,omitempty"`
+		Tax    *InvoiceTax `json:"tax,omitempty"`
+	}
+
+	// InvoiceCustomAmount is a labeled extra amount, e.g. a packing fee
+	InvoiceCustomAmount struct {
+		Label  string `json:"label"`
+		Amount *Money `json:"amount,omitempty"`
+	}
+
+	// InvoicePayments are the payments recorded on an invoice
+	InvoicePayments struct {
+		PaidAmount   *Money                 `json:"paid_amount,omitempty"`
+		Transactions []InvoicePaymentDetail `json:"transactions,omitempty"`
+	}
+
+	// InvoicePaymentDetail is a payment, RecordInvoicePayment records those made outside PayPal
+	InvoicePaymentDetail struct {
+		Type         string          `json:"type,omitempty"`
+		PaymentID    string          `json:"payment_id,omitempty"`
+		PaymentDate  string          `json:"payment_date,omitempty"`
+		Method       string          `json:"method"`
+		Note         string          `json:"note,omitempty"`
+		Amount       *Money          `json:"amount,omitempty"`
+		ShippingInfo *InvoiceContact `json:"shipping_info,omitempty"`
+	}
+
+	// InvoiceRefunds are the refunds recorded on an invoice
+	InvoiceRefunds struct {
+		RefundAmount *Money                `json:"refund_amount,omitempty"`
+		Transactions []InvoiceRefundDetail `json:"transactions,omitempty"`
+	}
+
+	// InvoiceRefundDetail is a refund, RecordInvoiceRefund records those made outside PayPal
+	InvoiceRefundDetail struct {
+		Type       string `json:"type,omitempty"`
+		RefundID   string `json:"refund_id,omitempty"`
+		RefundDate string `json:"refund_date,omitempty"`
+		Amount     *Money `json:"amount,omitempty"`
+		Method     string `json:"method"`
+	}
+
+	// InvoiceNotification is the email sent with SendInvoice, RemindInvoice and CancelInvoice
+	InvoiceNotification struct {
+		Subject              string   `json:"subject,omitempty"`
+		Note                 string   `json:"note,omitempty"`
+		SendToInvoicer       bool     `json:"send_to_invoicer,omitempty"`
+		SendToRecipient      bool     `json:"send_to_recipient,omitempty"`
+		AdditionalRecipients []string `json:"additional_recipients,omitempty"`
+	}
+
+	// InvoiceNumber is returned by GenerateNextInvoiceNumber
+	InvoiceNumber struct {
+		InvoiceNumber string `json:"invoice_number"`
+	}
+
+	// InvoiceListParameters pages ListInvoices
+	InvoiceListParameters struct {
+		Fields string // e.g. "all", only the invoice summary when empty
+		ListParams
+	}
+
+	// ListInvoicesResponse is one page of ListInvoices or SearchInvoices
+	ListInvoicesResponse struct {
+		Items []Invoice `json:"items"`
+		SharedListResponse
+	}
+
+	// InvoiceSearchRequest filters SearchInvoices, dates are YYYY-MM-DD
+	//Doc: https://developer.paypal.com/docs/api/invoicing/v2/#invoices_search-invoices
+	InvoiceSearchRequest struct {
+		RecipientEmail        string              `json:"recipient_email,omitempty"`
+		RecipientFirstName    string              `json:"recipient_first_name,omitempty"`
+		RecipientLastName     string              `json:"recipient_last_name,omitempty"`
+		RecipientBusinessName string              `json:"recipient_business_name,omitempty"`
+		InvoiceNumber         string              `json:"invoice_number,omitempty"`
+		Status                []string            `json:"status,omitempty"`
+		Reference             string              `json:"reference,omitempty"`
+		CurrencyCode          string              `json:"currency_code,omitempty"`
+		Memo                  string              `json:"memo,omitempty"`
+		TotalAmountRange      *InvoiceAmountRange `json:"total_amount_range,omitempty"`
+		InvoiceDateRange      *InvoiceDateRange   `json:"invoice_date_range,omitempty"`
+		DueDateRange          *InvoiceDateRange   `json:"due_date_range,omitempty"`
+		PaymentDateRange      *InvoiceDateRange   `json:"payment_date_range,omitempty"`
+		CreationDateRange     *InvoiceDateRange   `json:"creation_date_range,omitempty"`
+		Archived              *bool               `json:"archived,omitempty"`
+		Fields                []string            `json:"fields,omitempty"`
+	}
+
+	// InvoiceAmountRange bounds the total of searched invoices
+	InvoiceAmountRange struct {
+		LowerAmount Money `json:"lower_amount"`
+		UpperAmount Money `json:"upper_amount"`
+	}
+
+	// InvoiceDateRange bounds a date of searched invoices
+	InvoiceDateRange struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+
+	// InvoiceQRCodeRequest sizes the QR code of GenerateInvoiceQRCode, 150 to 500 pixels
+	InvoiceQRCodeRequest struct {
+		Width  int    `json:"width,omitempty"`
+		Height int    `json:"height,omitempty"`
+		Action string `json:"action,omitempty"`
+	}
+
+	// InvoiceRecordResponse identifies a recorded payment or refund
+	InvoiceRecordResponse struct {
+		PaymentID string `json:"payment_id,omitempty"`
+		RefundID  string `json:"refund_id,omitempty"`
+	}
+
+	// InvoiceTemplate prefills the invoices created from it
+	InvoiceTemplate struct {
+		ID              string                   `json:"id,omitempty"`
+		Name            string                   `json:"name"`
+		DefaultTemplate bool                     `json:"default_template,omitempty"`
+		TemplateInfo    *InvoiceTemplateInfo     `json:"template_info,omitempty"`
+		Settings        *InvoiceTemplateSettings `json:"settings,omitempty"`
+		UnitOfMeasure   string                   `json:"unit_of_measure,omitempty"`
+		Standard        bool                     `json:"standard_template,omitempty"`
+		Links           []Link                   `json:"links,omitempty"`
+	}
+
+	// InvoiceTemplateInfo holds the invoice fields a template prefills
+	InvoiceTemplateInfo struct {
+		Detail               *InvoiceDetail        `json:"detail,omitempty"`
+		Invoicer             *Invoicer             `json:"invoicer,omitempty"`
+		PrimaryRecipients    []InvoiceRecipient    `json:"primary_recipients,omitempty"`
+		AdditionalRecipients []InvoiceEmail        `json:"additional_recipients,omitempty"`
+		Items                []InvoiceItem         `json:"items,omitempty"`
+		Configuration        *InvoiceConfiguration `json:"configuration,omitempty"`
+		Amount               *InvoiceAmount        `json:"amount,omitempty"`
+		DueAmount            *Money                `json:"due_amount,omitempty"`
+	}
+
+	// InvoiceTemplateSettings hides item and subtotal fields of invoices
+	InvoiceTemplateSettings struct {
+		TemplateItemSettings     []InvoiceTemplateFieldSetting `json:"template_item_settings,omitempty"`
+		TemplateSubtotalSettings []InvoiceTemplateFieldSetting `json:"template_subtotal_settings,omitempty"`
+	}
+
+	// InvoiceTemplateFieldSetting shows or hides one field, e.g. "items.date"
+	InvoiceTemplateFieldSetting struct {
+		FieldName         string                  `json:"field_name"`
+		DisplayPreference *InvoiceFieldPreference `json:"display_preference,omitempty"`
+	}
+
+	// InvoiceFieldPreference of an InvoiceTemplateFieldSetting
+	InvoiceFieldPreference struct {
+		Hidden bool `json:"hidden"`
+	}
+
+	// ListInvoiceTemplatesResponse is returned by ListInvoiceTemplates
+	ListInvoiceTemplatesResponse struct {
+		Templates []InvoiceTemplate `json:"templates"`
+		SharedListResponse
+	}
+
+	// Dispute is a customer complaint about a transaction
+	//Doc: https://developer.paypal.com/docs/api/customer-disputes/v1/
+	Dispute struct {
+		DisputeID             string                `json:"dispute_id"`
+		CreateTime            *JSONTime             `json:"create_time,omitempty"`
+		UpdateTime            *JSONTime             `json:"update_time,omitempty"`
+		DisputedTransactions  []DisputedTransaction `json:"disputed_transactions,omitempty"`
+		Reason                string                `json:"reason"`
+		Status                string                `json:"status"`
+		DisputeState          string                `json:"dispute_state,omitempty"`
+		DisputeAmount         *Money                `json:"dispute_amount,omitempty"`
+		DisputeOutcome        *DisputeOutcome       `json:"dispute_outcome,omitempty"`
+		DisputeLifeCycleStage string                `json:"dispute_life_cycle_stage"`
+		DisputeChannel        string                `json:"dispute_channel,omitempty"`
+		Messages              []DisputeMessage      `json:"messages,omitempty"`
+		Offer                 *DisputeOffer         `json:"offer,omitempty"`
+		Evidences             []DisputeEvidence     `json:"evidences,omitempty"`
+		SellerResponseDueDate *JSONTime             `json:"seller_response_due_date,omitempty"`
+		BuyerResponseDueDate  *JSONTime             `json:"buyer_response_due_date,omitempty"`
+		Links                 []Link                `json:"links,omitempty"`
+	}
+
+	// DisputedTransaction is the transaction a dispute is about
+	DisputedTransaction struct {
+		SellerTransactionID string        `json:"seller_transaction_id,omitempty"`
+		BuyerTransactionID  string        `json:"buyer_transaction_id,omitempty"`
+		CreateTime          *JSONTime     `json:"create_time,omitempty"`
+		TransactionStatus   string        `json:"transaction_status,omitempty"`
+		GrossAmount         *Money        `json:"gross_amount,omitempty"`
+		InvoiceNumber       string        `json:"invoice_number,omitempty"`
+		Custom              string        `json:"custom,omitempty"`
+		Buyer               *DisputeParty `json:"buyer,omitempty"`
+		Seller              *DisputeParty `json:"seller,omitempty"`
+	}
+
+	// DisputeParty is the buyer or seller of a disputed transaction
+	DisputeParty struct {
+		Email      string `json:"email,omitempty"`
+		MerchantID string `json:"merchant_id,omitempty"`
+		Name       string `json:"name,omitempty"`
+	}
+
+	// DisputeOutcome is how a resolved dispute ended
+	DisputeOutcome struct {
+		OutcomeCode    string `json:"outcome_code"`
+		AmountRefunded *Money `json:"amount_refunded,omitempty"`
+	}
+
+	// DisputeMessage is a message between buyer and seller
+	DisputeMessage struct {
+		PostedBy   string    `json:"posted_by"`
+		TimePosted *JSONTime `json:"time_posted,omitempty"`
+		Content    string    `json:"content"`
+	}
+
+	// DisputeOffer is what the buyer asked for and the seller offered
+	DisputeOffer struct {
+		BuyerRequestedAmount *Money `json:"buyer_requested_amount,omitempty"`
+		SellerOfferedAmount  *Money `json:"seller_offered_amount,omitempty"`
+		OfferType            string `json:"offer_type,omitempty"`
+	}
+
+	// DisputeEvidence describes evidence, its documents are uploaded as EvidenceFile
+	DisputeEvidence struct {
+		EvidenceType string               `json:"evidence_type"`
+		EvidenceInfo *DisputeEvidenceInfo `json:"evidence_info,omitempty"`
+		Documents    []DisputeDocument    `json:"documents,omitempty"`
+		Notes        string               `json:"notes,omitempty"`
+		ItemID       string               `json:"item_id,omitempty"`
+	}
+
+	// DisputeEvidenceInfo proves shipment or refund
+	DisputeEvidenceInfo struct {
+		TrackingInfo []DisputeTrackingInfo `json:"tracking_info,omitempty"`
+		RefundIDs    []DisputeRefundID     `json:"refund_ids,omitempty"`
+	}
+
+	// DisputeTrackingInfo is a shipment tracking number
+	DisputeTrackingInfo struct {
+		CarrierName      string `json:"carrier_name"`
+		CarrierNameOther string `json:"carrier_name_other,omitempty"`
+		TrackingURL      string `json:"tracking_url,omitempty"`
+		TrackingNumber   string `json:"tracking_number"`
+	}
+
+	// DisputeRefundID is a refund issued for the disputed transaction
+	DisputeRefundID struct {
+		RefundID string `json:"refund_id"`
+	}
+
+	// DisputeDocument is an uploaded evidence document
+	DisputeDocument struct {
+		Name string `json:"name"`
+		URL  string `json:"url,omitempty"`
+	}
+
+	// DisputeListParams filters ListDisputes, zero values are not sent
+	DisputeListParams struct {
+		StartTime             time.Time
+		DisputedTransactionID string
+		DisputeState          string
+		UpdateTimeBefore      time.Time
+		UpdateTimeAfter       time.Time
+		PageSize              int    // At most 50
+		NextPageToken         string // From ListDisputesResponse.NextPageToken
+	}
+
+	// ListDisputesResponse is one page of ListDisputes
+	ListDisputesResponse struct {
+		Items []Dispute `json:"items"`
+		Links []Link    `json:"links"`
+	}
+
+	// AcceptClaimRequest accepts liability for a dispute, refunding the buyer
+	AcceptClaimRequest struct {
+		Note                  string                         `json:"note"`
+		AcceptClaimReason     string                         `json:"accept_claim_reason,omitempty"`
+		InvoiceID             string                         `json:"invoice_id,omitempty"`
+		ReturnShippingAddress *ShippingDetailAddressPortable `json:"return_shipping_address,omitempty"`
+		RefundAmount          *Money                         `json:"refund_amount,omitempty"`
+	}
+
+	// MakeOfferRequest offers the buyer a resolution of an INQUIRY
+	MakeOfferRequest struct {
+		Note                  string                         `json:"note"`
+		OfferAmount           *Money                         `json:"offer_amount,omitempty"`
+		ReturnShippingAddress *ShippingDetailAddressPortable `json:"return_shipping_address,omitempty"`
+		InvoiceID             string                         `json:"invoice_id,omitempty"`
+		OfferType             string                         `json:"offer_type"`
+	}
+
+	// ProvideEvidenceRequest is the input part of ProvideDisputeEvidence and AppealDispute
+	ProvideEvidenceRequest struct {
+		Evidences []DisputeEvidence `json:"evidences"`
+	}
+
+	// DisputeActionResponse links to the dispute an action was taken on
+	DisputeActionResponse struct {
+		Links []Link `json:"links"`
+	}
+
+	// VaultCustomer is the merchant's customer payment tokens are saved for,
+	// PayPal assigns an ID when it is empty
+	//Doc: https://developer.paypal.com/docs/api/payment-tokens/v3/
+	VaultCustomer struct {
+		ID string `json:"id,omitempty"`
+	}
+
+	// VaultPaymentSource is the card or PayPal wallet of a setup or payment token
+	VaultPaymentSource struct {
+		Card   *VaultCard   `json:"card,omitempty"`
+		PayPal *VaultPayPal `json:"paypal,omitempty"`
+	}
+
+	// VaultCard is a card to save, Brand and LastDigits are set by PayPal
+	VaultCard struct {
+		Name               string                         `json:"name,omitempty"`
+		Number             string                         `json:"number,omitempty"`
+		Expiry             string                         `json:"expiry,omitempty"`
+		SecurityCode       string                         `json:"security_code,omitempty"`
+		BillingAddress     *ShippingDetailAddressPortable `json:"billing_address,omitempty"`
+		VerificationMethod string                         `json:"verification_method,omitempty"`
+		ExperienceContext  *VaultExperienceContext        `json:"experience_context,omitempty"`
+		Brand              string                         `json:"brand,omitempty"`
+		LastDigits         string                         `json:"last_digits,omitempty"`
+	}
+
+	// VaultPayPal is a PayPal wallet to save, the payer approves it through
+	// the "approve" link of the setup token
+	VaultPayPal struct {
+		Description                 string                  `json:"description,omitempty"`
+		UsageType                   string                  `json:"usage_type,omitempty"`
+		CustomerType                string                  `json:"customer_type,omitempty"`
+		PermitMultiplePaymentTokens bool                    `json:"permit_multiple_payment_tokens,omitempty"`
+		ExperienceContext           *VaultExperienceContext `json:"experience_context,omitempty"`
+		EmailAddress                string                  `json:"email_address,omitempty"`
+		PayerID                     string                  `json:"payer_id,omitempty"`
+	}
+
+	// VaultExperienceContext is where the payer returns to after approving
+	VaultExperienceContext struct {
+		BrandName          string `json:"brand_name,omitempty"`
+		Locale             string `json:"locale,omitempty"`
+		ReturnURL          string `json:"return_url,omitempty"`
+		CancelURL          string `json:"cancel_url,omitempty"`
+		ShippingPreference string `json:"shipping_preference,omitempty"`
+	}
+
+	// SetupTokenRequest starts saving a payment source
+	SetupTokenRequest struct {
+		Customer      *VaultCustomer     `json:"customer,omitempty"`
+		PaymentSource VaultPaymentSource `json:"payment_source"`
+	}
+
+	// SetupToken is a payment source on its way into the vault
+	SetupToken struct {
+		ID            string              `json:"id"`
+		Customer      *VaultCustomer      `json:"customer,omitempty"`
+		Status        string              `json:"status"`
+		PaymentSource *VaultPaymentSource `json:"payment_source,omitempty"`
+		Links         []Link              `json:"links,omitempty"`
+	}
+
+	// PaymentTokenRequest turns an approved setup token into a payment token
+	PaymentTokenRequest struct {
+		Customer      *VaultCustomer            `json:"customer,omitempty"`
+		PaymentSource PaymentTokenRequestSource `json:"payment_source"`
+	}
+
+	// PaymentTokenRequestSource references the setup token of a PaymentTokenRequest
+	PaymentTokenRequestSource struct {
+		Token *PaymentSourceToken `json:"token"`
+	}
+
+	// PaymentToken is a saved payment source, orders are paid with it through VaultedPaymentSource
+	PaymentToken struct {
+		ID            string              `json:"id"`
+		Customer      *VaultCustomer      `json:"customer,omitempty"`
+		PaymentSource *VaultPaymentSource `json:"payment_source,omitempty"`
+		Links         []Link              `json:"links,omitempty"`
+	}
+
+	// PaymentTokenListParams pages ListPaymentTokens
+	PaymentTokenListParams struct {
+		Page          int
+		PageSize      int
+		TotalRequired bool
+	}
+
+	// ListPaymentTokensResponse is one page of the payment tokens of a customer
+	ListPaymentTokensResponse struct {
+		Customer      *VaultCustomer `json:"customer,omitempty"`
+		PaymentTokens []PaymentToken `json:"payment_tokens"`
+		TotalItems    int            `json:"total_items,omitempty"`
+		TotalPages    int            `json:"total_pages,omitempty"`
+		Links         []Link         `json:"links,omitempty"`
 	}
 
 	ListParams struct {
@@ -616,9 +1979,12 @@ type (
 
 	// ErrorResponseDetail struct
 	ErrorResponseDetail struct {
-		Field string `json:"field"`
-		Issue string `json:"issue"`
-		Links []Link `json:"link"`
+		Field       string `json:"field"`
+		Value       string `json:"value,omitempty"`
+		Location    string `json:"location,omitempty"`
+		Issue       string `json:"issue"`
+		Description string `json:"description,omitempty"`
+		Links       []Link `json:"link"`
 	}
 )
 
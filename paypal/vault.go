@@ -0,0 +1,187 @@
+/**
+ * @ClassName vault
+ * @Description vault v3 setup tokens and payment tokens
+ * @Author liwei
+ * @Date 2026/10/18 23:10
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CreateSetupToken starts saving a card or PayPal wallet. A PayPal wallet
+// must be approved by the payer through the "approve" link first.
+// Endpoint: POST /v3/vault/setup-tokens
+func (c *Client) CreateSetupToken(ctx context.Context, setupToken SetupTokenRequest) (*SetupToken, error) {
+	return c.CreateSetupTokenWithPaypalRequestID(ctx, setupToken, "")
+}
+
+// CreateSetupTokenWithPaypalRequestID - Use this call to create a setup token with idempotency
+// Endpoint: POST /v3/vault/setup-tokens
+func (c *Client) CreateSetupTokenWithPaypalRequestID(ctx context.Context, setupToken SetupTokenRequest, requestID string) (*SetupToken, error) {
+	response := &SetupToken{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v3/vault/setup-tokens"), setupToken)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetSetupToken returns a setup token, e.g. to check it was APPROVED
+// Endpoint: GET /v3/vault/setup-tokens/ID
+func (c *Client) GetSetupToken(ctx context.Context, setupTokenID string) (*SetupToken, error) {
+	response := &SetupToken{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v3/vault/setup-tokens/", setupTokenID), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// CreatePaymentToken saves the payment source of an approved setup token
+// Endpoint: POST /v3/vault/payment-tokens
+func (c *Client) CreatePaymentToken(ctx context.Context, setupTokenID string, customer *VaultCustomer) (*PaymentToken, error) {
+	return c.CreatePaymentTokenWithPaypalRequestID(ctx, setupTokenID, customer, "")
+}
+
+// CreatePaymentTokenWithPaypalRequestID - Use this call to create a payment token with idempotency
+// Endpoint: POST /v3/vault/payment-tokens
+func (c *Client) CreatePaymentTokenWithPaypalRequestID(ctx context.Context, setupTokenID string, customer *VaultCustomer, requestID string) (*PaymentToken, error) {
+	response := &PaymentToken{}
+
+	paymentToken := PaymentTokenRequest{
+		Customer: customer,
+		PaymentSource: PaymentTokenRequestSource{
+			Token: &PaymentSourceToken{ID: setupTokenID, Type: PaymentSourceTokenSetupToken},
+		},
+	}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v3/vault/payment-tokens"), paymentToken)
+	if err != nil {
+		return response, err
+	}
+
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetPaymentToken returns a saved payment source
+// Endpoint: GET /v3/vault/payment-tokens/ID
+func (c *Client) GetPaymentToken(ctx context.Context, paymentTokenID string) (*PaymentToken, error) {
+	response := &PaymentToken{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v3/vault/payment-tokens/", paymentTokenID), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// ListPaymentTokens returns one page of the payment sources saved for a customer, params may be nil
+// Endpoint: GET /v3/vault/payment-tokens?customer_id=ID
+func (c *Client) ListPaymentTokens(ctx context.Context, customerID string, params *PaymentTokenListParams) (*ListPaymentTokensResponse, error) {
+	response := &ListPaymentTokensResponse{}
+
+	if customerID == "" {
+		return response, errors.New("paypal: listing payment tokens needs a customer ID")
+	}
+
+	q := url.Values{}
+	q.Set("customer_id", customerID)
+	if params != nil {
+		if params.Page > 0 {
+			q.Set("page", strconv.Itoa(params.Page))
+		}
+		if params.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(params.PageSize))
+		}
+		if params.TotalRequired {
+			q.Set("total_required", "true")
+		}
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v3/vault/payment-tokens"), q), nil)
+	if err != nil {
+		return response, err
+	}
+
+	if err = c.SendWithAuth(req, response); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// DeletePaymentToken removes a saved payment source from the vault
+// Endpoint: DELETE /v3/vault/payment-tokens/ID
+func (c *Client) DeletePaymentToken(ctx context.Context, paymentTokenID string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s%s%s", c.Domain, "/v3/vault/payment-tokens/", paymentTokenID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// Link returns the HATEOAS link with the given rel, e.g. "approve"
+func (t *SetupToken) Link(rel string) *Link {
+	for i := range t.Links {
+		if t.Links[i].Rel == rel {
+			return &t.Links[i]
+		}
+	}
+	return nil
+}
+
+// VaultedPaymentSource returns the payment source that pays an order with a
+// saved card or PayPal wallet, for CreateOrderWithPaymentSource or CaptureOrder.
+// The token must carry its payment source, as returned by GetPaymentToken or
+// ListPaymentTokens.
+func VaultedPaymentSource(token *PaymentToken) (*PaymentSource, error) {
+	if token == nil || token.PaymentSource == nil {
+		return nil, errors.New("paypal: payment token without a payment source")
+	}
+
+	switch {
+	case token.PaymentSource.PayPal != nil:
+		return &PaymentSource{PayPal: &PaymentSourcePayPal{VaultID: token.ID}}, nil
+	case token.PaymentSource.Card != nil:
+		return &PaymentSource{Card: &PaymentSourceCard{VaultID: token.ID}}, nil
+	}
+	return nil, fmt.Errorf("paypal: payment token %s has an unknown payment source", token.ID)
+}
@@ -0,0 +1,144 @@
+package paypal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestListPaymentTokens(t *testing.T) {
+	rec := newAPIRecorder(t, `{
+		"customer": {"id": "customer_4029352050"},
+		"payment_tokens": [{"id": "8kk8451t", "payment_source": {"card": {"brand": "VISA", "last_digits": "1111"}}}],
+		"total_items": 1,
+		"total_pages": 1
+	}`)
+	c := rec.client(t, nil)
+
+	if _, err := c.ListPaymentTokens(context.Background(), "", nil); err == nil {
+		t.Error("listed payment tokens without a customer ID")
+	}
+
+	response, err := c.ListPaymentTokens(context.Background(), "customer_4029352050", &PaymentTokenListParams{Page: 2, PageSize: 5, TotalRequired: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "GET" || req.Path != "/v3/vault/payment-tokens" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Query.Encode(); got != "customer_id=customer_4029352050&page=2&page_size=5&total_required=true" {
+		t.Errorf("query %s", got)
+	}
+	if len(response.PaymentTokens) != 1 || response.PaymentTokens[0].PaymentSource.Card.LastDigits != "1111" {
+		t.Errorf("response %+v", response)
+	}
+
+	if _, err = c.ListPaymentTokens(context.Background(), "customer_4029352050", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Query.Encode(); got != "customer_id=customer_4029352050" {
+		t.Errorf("query without params %s", got)
+	}
+}
+
+func TestCreatePaymentToken(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id": "8kk8451t", "customer": {"id": "customer_4029352050"}, "payment_source": {"paypal": {"email_address": "buyer@example.com"}}}`)
+	c := rec.client(t, nil)
+
+	token, err := c.CreatePaymentTokenWithPaypalRequestID(context.Background(), "5C991763VB2781612", &VaultCustomer{ID: "customer_4029352050"}, "vault-5C991763VB2781612")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	if req.Method != "POST" || req.Path != "/v3/vault/payment-tokens" {
+		t.Fatalf("sent %s %s", req.Method, req.Path)
+	}
+	if got := req.Header.Get("PayPal-Request-Id"); got != "vault-5C991763VB2781612" {
+		t.Errorf("PayPal-Request-Id %q", got)
+	}
+	if want := `{"customer":{"id":"customer_4029352050"},"payment_source":{"token":{"id":"5C991763VB2781612","type":"SETUP_TOKEN"}}}`; !jsonEqual(t, req.Body, want) {
+		t.Errorf("body %s, want %s", req.Body, want)
+	}
+	if token.ID != "8kk8451t" || token.PaymentSource.PayPal.EmailAddress != "buyer@example.com" {
+		t.Errorf("token %+v", token)
+	}
+}
+
+func TestVaultedPaymentSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *PaymentToken
+		want  *PaymentSource
+	}{
+		{
+			name:  "paypal",
+			token: &PaymentToken{ID: "8kk8451t", PaymentSource: &VaultPaymentSource{PayPal: &VaultPayPal{EmailAddress: "buyer@example.com"}}},
+			want:  &PaymentSource{PayPal: &PaymentSourcePayPal{VaultID: "8kk8451t"}},
+		},
+		{
+			name:  "card",
+			token: &PaymentToken{ID: "3ua4z4w3", PaymentSource: &VaultPaymentSource{Card: &VaultCard{Brand: "VISA", LastDigits: "1111"}}},
+			want:  &PaymentSource{Card: &PaymentSourceCard{VaultID: "3ua4z4w3"}},
+		},
+		{name: "nil token"},
+		{name: "no payment source", token: &PaymentToken{ID: "8kk8451t"}},
+		{name: "unknown payment source", token: &PaymentToken{ID: "8kk8451t", PaymentSource: &VaultPaymentSource{}}},
+	}
+	for _, tt := range tests {
+		got, err := VaultedPaymentSource(tt.token)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		switch {
+		case tt.want.PayPal != nil && (got.PayPal == nil || *got.PayPal != *tt.want.PayPal || got.Card != nil):
+			t.Errorf("%s: got %+v", tt.name, got)
+		case tt.want.Card != nil && (got.Card == nil || *got.Card != *tt.want.Card || got.PayPal != nil):
+			t.Errorf("%s: got %+v", tt.name, got)
+		}
+	}
+}
+
+func TestCreateOrderWithPaymentSourceRequestID(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id": "5O190127TN364715T", "status": "COMPLETED"}`)
+	c := rec.client(t, nil)
+
+	source, err := VaultedPaymentSource(&PaymentToken{ID: "8kk8451t", PaymentSource: &VaultPaymentSource{PayPal: &VaultPayPal{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	units := []PurchaseUnitRequest{{Amount: &PurchaseUnitAmount{Currency: "USD", Value: "15.00"}}}
+	if _, err = c.CreateOrderWithPaymentSource(context.Background(), OrderIntentCapture, units, source, ""); err != nil {
+		t.Fatal(err)
+	}
+	req := rec.last(t)
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	generated := req.Header.Get("PayPal-Request-Id")
+	if !uuid.MatchString(generated) {
+		t.Errorf("generated PayPal-Request-Id %q", generated)
+	}
+	if want := `{"intent":"CAPTURE","purchase_units":[{"amount":{"currency_code":"USD","value":"15.00"}}],"payment_source":{"paypal":{"vault_id":"8kk8451t"}}}`; !jsonEqual(t, req.Body, want) {
+		t.Errorf("body %s, want %s", req.Body, want)
+	}
+
+	if _, err = c.CreateOrderWithPaymentSource(context.Background(), OrderIntentCapture, units, source, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Header.Get("PayPal-Request-Id"); got == generated || !uuid.MatchString(got) {
+		t.Errorf("second order PayPal-Request-Id %q", got)
+	}
+
+	if _, err = c.CreateOrderWithPaymentSource(context.Background(), OrderIntentCapture, units, source, "order-customer-42"); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.last(t).Header.Get("PayPal-Request-Id"); got != "order-customer-42" {
+		t.Errorf("PayPal-Request-Id %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+/**
+ * @ClassName webhook
+ * @Description webhook subscription management
+ * @Author liwei
+ * @Date 2026/10/18 11:20
+ * @Version example V1.0
+ **/
+
+package paypal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CreateWebhook subscribes url to eventTypes, use WebhookEventType{Name: "*"} for all events
+// Endpoint: POST /v1/notifications/webhooks
+func (c *Client) CreateWebhook(ctx context.Context, createWebhookRequest *CreateWebhookRequest) (*Webhook, error) {
+	webhook := &Webhook{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/webhooks"), createWebhookRequest)
+	if err != nil {
+		return webhook, err
+	}
+
+	if err = c.SendWithAuth(req, webhook); err != nil {
+		return webhook, err
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks lists the webhooks of the app, anchorType is APPLICATION (default) or ACCOUNT
+// Endpoint: GET /v1/notifications/webhooks
+func (c *Client) ListWebhooks(ctx context.Context, anchorType string) (*ListWebhookResponse, error) {
+	resp := &ListWebhookResponse{}
+
+	q := url.Values{}
+	if anchorType != "" {
+		q.Set("anchor_type", anchorType)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/webhooks"), q), nil)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = c.SendWithAuth(req, resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+// Endpoint: GET /v1/notifications/webhooks/ID
+func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	webhook := &Webhook{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s%s", c.Domain, "/v1/notifications/webhooks/", webhookID), nil)
+	if err != nil {
+		return webhook, err
+	}
+
+	if err = c.SendWithAuth(req, webhook); err != nil {
+		return webhook, err
+	}
+
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the url and/or event types of a webhook, the
+// supported operations are "replace" on "/url" and "/event_types"
+// Endpoint: PATCH /v1/notifications/webhooks/ID
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, operations []PatchOperation) (*Webhook, error) {
+	webhook := &Webhook{}
+
+	req, err := c.NewRequest(ctx, "PATCH", fmt.Sprintf("%s%s%s", c.Domain, "/v1/notifications/webhooks/", webhookID), operations)
+	if err != nil {
+		return webhook, err
+	}
+
+	if err = c.SendWithAuth(req, webhook); err != nil {
+		return webhook, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook by ID
+// Endpoint: DELETE /v1/notifications/webhooks/ID
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	req, err := c.NewRequest(ctx, "DELETE", fmt.Sprintf("%s%s%s", c.Domain, "/v1/notifications/webhooks/", webhookID), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.SendWithAuth(req, nil)
+}
+
+// ListAvailableEventTypes lists the event types a webhook can subscribe to
+// Endpoint: GET /v1/notifications/webhooks-event-types
+func (c *Client) ListAvailableEventTypes(ctx context.Context) (*WebhookEventTypesResponse, error) {
+	resp := &WebhookEventTypesResponse{}
+
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/webhooks-event-types"), nil)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = c.SendWithAuth(req, resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// ListWebhookEvents lists the events delivered to the webhooks of the app
+// Endpoint: GET /v1/notifications/webhooks-events
+func (c *Client) ListWebhookEvents(ctx context.Context, params *ListWebhookEventsParams) (*ListWebhookEventsResponse, error) {
+	resp := &ListWebhookEventsResponse{}
+
+	q := url.Values{}
+	if params != nil {
+		q = params.values()
+	}
+
+	req, err := c.NewRequest(ctx, "GET", withQuery(fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/webhooks-events"), q), nil)
+	if err != nil {
+		return resp, err
+	}
+
+	if err = c.SendWithAuth(req, resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// ResendWebhookEvent redelivers an event, to all subscribed webhooks when webhookIDs is empty
+// Endpoint: POST /v1/notifications/webhooks-events/ID/resend
+func (c *Client) ResendWebhookEvent(ctx context.Context, eventID string, webhookIDs []string) (*WebhookEvent, error) {
+	type resendWebhookEventRequest struct {
+		WebhookIDs []string `json:"webhook_ids,omitempty"`
+	}
+
+	event := &WebhookEvent{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s%s%s", c.Domain, "/v1/notifications/webhooks-events/", eventID, "/resend"), resendWebhookEventRequest{WebhookIDs: webhookIDs})
+	if err != nil {
+		return event, err
+	}
+
+	if err = c.SendWithAuth(req, event); err != nil {
+		return event, err
+	}
+
+	return event, nil
+}
+
+// SimulateWebhookEvent sends a sample event of the given type to a webhook, sandbox only
+// Endpoint: POST /v1/notifications/simulate-event
+func (c *Client) SimulateWebhookEvent(ctx context.Context, simulateRequest *SimulateWebhookEventRequest) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+
+	req, err := c.NewRequest(ctx, "POST", fmt.Sprintf("%s%s", c.Domain, "/v1/notifications/simulate-event"), simulateRequest)
+	if err != nil {
+		return event, err
+	}
+
+	if err = c.SendWithAuth(req, event); err != nil {
+		return event, err
+	}
+
+	return event, nil
+}
+
+func (p *ListWebhookEventsParams) values() url.Values {
+	q := url.Values{}
+	if p.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+	if !p.StartTime.IsZero() {
+		q.Set("start_time", p.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !p.EndTime.IsZero() {
+		q.Set("end_time", p.EndTime.UTC().Format(time.RFC3339))
+	}
+	if p.TransactionID != "" {
+		q.Set("transaction_id", p.TransactionID)
+	}
+	if p.EventType != "" {
+		q.Set("event_type", p.EventType)
+	}
+	return q
+}
@@ -0,0 +1,149 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestListWebhookEvents(t *testing.T) {
+	rec := newAPIRecorder(t, `{
+		"events": [
+			{"id": "WH-1", "event_type": "PAYMENT.CAPTURE.COMPLETED", "create_time": "2021-07-07T17:53:00.000Z", "resource": {"id": "2GG279541U471931P"}},
+			{"id": "WH-2", "event_type": "PAYMENT.CAPTURE.REFUNDED", "create_time": "2021-07-07T17:54:00.000Z", "resource": {"id": "1JU08902781691411"}}
+		],
+		"count": 2,
+		"links": [
+			{"href": "https://api-m.sandbox.paypal.com/v1/notifications/webhooks-events?page_size=2&end_time=2021-07-07T17:53:00Z", "rel": "next", "method": "GET"}
+		]
+	}`)
+	c := rec.client(t, nil)
+
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.FixedZone("", -7*60*60))
+	resp, err := c.ListWebhookEvents(context.Background(), &ListWebhookEventsParams{
+		PageSize:  2,
+		StartTime: start,
+		EndTime:   start.Add(7 * 24 * time.Hour),
+		EventType: EventPaymentCaptureCompleted,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := rec.last(t)
+	if req.Method != "GET" || req.Path != "/v1/notifications/webhooks-events" {
+		t.Fatalf("%s %s", req.Method, req.Path)
+	}
+	wantQuery := map[string]string{
+		"page_size":  "2",
+		"start_time": "2021-07-01T07:00:00Z",
+		"end_time":   "2021-07-08T07:00:00Z",
+		"event_type": "PAYMENT.CAPTURE.COMPLETED",
+	}
+	for k, v := range wantQuery {
+		if got := req.Query.Get(k); got != v {
+			t.Errorf("query %s = %q, want %q", k, got, v)
+		}
+	}
+	if len(req.Query) != len(wantQuery) {
+		t.Errorf("query %v", req.Query)
+	}
+
+	if resp.Count != 2 || len(resp.Events) != 2 || resp.Events[1].ID != "WH-2" {
+		t.Fatalf("response %+v", resp)
+	}
+	if len(resp.Links) != 1 || resp.Links[0].Rel != "next" {
+		t.Fatalf("links %+v", resp.Links)
+	}
+
+	if _, err = c.ListWebhookEvents(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if req = rec.last(t); len(req.Query) != 0 {
+		t.Errorf("query without params %v", req.Query)
+	}
+}
+
+func TestWebhookRequests(t *testing.T) {
+	rec := newAPIRecorder(t, `{"id":"0EH40505U7160970P","url":"https://example.com/paypal/webhooks","event_types":[{"name":"*"}]}`)
+	c := rec.client(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		method string
+		path   string
+		query  string
+		body   string
+	}{
+		{
+			name: "create",
+			call: func() error {
+				_, err := c.CreateWebhook(ctx, &CreateWebhookRequest{URL: "https://example.com/paypal/webhooks", EventTypes: []WebhookEventType{{Name: "*"}}})
+				return err
+			},
+			method: "POST", path: "/v1/notifications/webhooks",
+			body: `{"url":"https://example.com/paypal/webhooks","event_types":[{"name":"*"}]}`,
+		},
+		{
+			name:   "list",
+			call:   func() error { _, err := c.ListWebhooks(ctx, "APPLICATION"); return err },
+			method: "GET", path: "/v1/notifications/webhooks", query: "anchor_type=APPLICATION",
+		},
+		{
+			name:   "list without anchor type",
+			call:   func() error { _, err := c.ListWebhooks(ctx, ""); return err },
+			method: "GET", path: "/v1/notifications/webhooks",
+		},
+		{
+			name: "update",
+			call: func() error {
+				_, err := c.UpdateWebhook(ctx, "0EH40505U7160970P", []PatchOperation{{Operation: PatchOperationReplace, Path: "/url", Value: "https://example.com/paypal/v2"}})
+				return err
+			},
+			method: "PATCH", path: "/v1/notifications/webhooks/0EH40505U7160970P",
+			body: `[{"op":"replace","path":"/url","value":"https://example.com/paypal/v2"}]`,
+		},
+		{
+			name:   "delete",
+			call:   func() error { return c.DeleteWebhook(ctx, "0EH40505U7160970P") },
+			method: "DELETE", path: "/v1/notifications/webhooks/0EH40505U7160970P",
+		},
+		{
+			name:   "resend",
+			call:   func() error { _, err := c.ResendWebhookEvent(ctx, "WH-1", []string{"0EH40505U7160970P"}); return err },
+			method: "POST", path: "/v1/notifications/webhooks-events/WH-1/resend",
+			body: `{"webhook_ids":["0EH40505U7160970P"]}`,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		req := rec.last(t)
+		if req.Method != tt.method || req.Path != tt.path || req.Query.Encode() != tt.query {
+			t.Errorf("%s: sent %s %s?%s", tt.name, req.Method, req.Path, req.Query.Encode())
+		}
+		if tt.body != "" && !jsonEqual(t, req.Body, tt.body) {
+			t.Errorf("%s: body %s, want %s", tt.name, req.Body, tt.body)
+		}
+	}
+}
+
+// jsonEqual reports whether got and want encode the same JSON value
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("%s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("%s: %v", want, err)
+	}
+	gb, _ := json.Marshal(g)
+	wb, _ := json.Marshal(w)
+	return string(gb) == string(wb)
+}